@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// activityActions are the actions the events can be filtered by: the ones
+// of the events API, and pipeline for the pipelines of a project
+var activityActions = []string{
+	"pushed", "commented", "merged", "created", "closed", "reopened",
+	"approved", "updated", "destroyed", "joined", "left", "expired",
+	"pipeline",
+}
+
+// activityEvent is an event of the activity feed, from the events API or
+// a pipeline
+type activityEvent struct {
+	Time    time.Time `json:"time"`
+	Project string    `json:"project,omitempty"`
+	Author  string    `json:"author,omitempty"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target,omitempty"`
+	Title   string    `json:"title,omitempty"`
+}
+
+var activityCmd = &cobra.Command{
+	Use:   "activity [remote]",
+	Short: "Show the recent activity of a project or a user",
+	Long: heredoc.Doc(`
+		Show the events of a project: pushes, comments, merges, issues and
+		merge requests opened or closed, approvals..., along with the
+		pipelines of the project, the most recent first.
+
+		With --user, show the events of the given user across all the
+		projects instead, or only in the project when a remote is given.
+		Pipelines are not part of the events of users.
+
+		The dates given with --after and --before are excluded, as in the
+		GitLab API.`),
+	Example: heredoc.Doc(`
+		lab activity
+		lab activity upstream -n 50
+		lab activity --action pushed,merged --after 2024-07-01
+		lab activity --user janedoe --json
+		lab activity --action pipeline`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		username, _ := cmd.Flags().GetString("user")
+
+		// the events of a user don't need a project, unless a remote is
+		// given to limit them to it
+		var (
+			rn  string
+			err error
+		)
+		if username == "" || len(args) > 0 {
+			rn, _, err = parseArgsRemoteAndProject(args)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		actions, _ := cmd.Flags().GetStringSlice("action")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		numRet, _ := cmd.Flags().GetString("number")
+		num, err := strconv.Atoi(numRet)
+		if err != nil || num <= 0 {
+			num = -1
+		}
+		for _, a := range actions {
+			if !contains(activityActions, a) {
+				log.Fatalf("unknown action %q, use %s", a, strings.Join(activityActions, ", "))
+			}
+		}
+		after, err := activityDate(cmd, "after")
+		if err != nil {
+			log.Fatal(err)
+		}
+		before, err := activityDate(cmd, "before")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var events []activityEvent
+		if username != "" {
+			events, err = userActivity(username, rn, len(args) > 0, actions, after, before, num)
+		} else {
+			events, err = projectActivity(rn, actions, after, before, num)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(events, "", "  ")
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(out))
+			return
+		}
+
+		pager := newPager(cmd.Flags())
+		defer pager.Close()
+		writeActivity(os.Stdout, events, username != "")
+	},
+}
+
+// activityDate returns the date given with a flag, if any
+func activityDate(cmd *cobra.Command, name string) (*gitlab.ISOTime, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	date, err := gitlab.ParseISOTime(value)
+	if err != nil {
+		return nil, errors.Errorf("invalid date %q for --%s, use YYYY-MM-DD", value, name)
+	}
+	return &date, nil
+}
+
+// activityEventTypes returns the event types to query the API with, one
+// request being done per action as the API filters by a single one; a nil
+// type stands for all the events
+func activityEventTypes(actions []string) []*gitlab.EventTypeValue {
+	if len(actions) == 0 {
+		return []*gitlab.EventTypeValue{nil}
+	}
+	var types []*gitlab.EventTypeValue
+	for _, a := range actions {
+		if a != "pipeline" {
+			eventType := gitlab.EventTypeValue(a)
+			types = append(types, &eventType)
+		}
+	}
+	return types
+}
+
+func projectActivity(rn string, actions []string, after, before *gitlab.ISOTime, num int) ([]activityEvent, error) {
+	var events []activityEvent
+	for _, eventType := range activityEventTypes(actions) {
+		list, err := lab.ProjectEvents(rn, gitlab.ListProjectVisibleEventsOptions{
+			Action: eventType,
+			After:  after,
+			Before: before,
+		}, num)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range list {
+			events = append(events, activityFromProjectEvent(e))
+		}
+	}
+
+	if len(actions) == 0 || contains(actions, "pipeline") {
+		opts := gitlab.ListProjectPipelinesOptions{
+			OrderBy: gitlab.String("updated_at"),
+			Sort:    gitlab.String("desc"),
+		}
+		// the dates are excluded, as for the events
+		if after != nil {
+			t := time.Time(*after).AddDate(0, 0, 1)
+			opts.UpdatedAfter = &t
+		}
+		if before != nil {
+			t := time.Time(*before)
+			opts.UpdatedBefore = &t
+		}
+		pipelines, err := lab.PipelineList(rn, opts, num)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pipelines {
+			events = append(events, activityFromPipeline(p))
+		}
+	}
+	return sortActivity(events, num), nil
+}
+
+func userActivity(username, rn string, inProject bool, actions []string, after, before *gitlab.ISOTime, num int) ([]activityEvent, error) {
+	userID := getUserID(username)
+	if userID == nil {
+		return nil, errors.Errorf("%s user not found", username)
+	}
+
+	projectID := 0
+	if inProject {
+		project, err := lab.GetProject(rn)
+		if err != nil {
+			return nil, err
+		}
+		projectID = project.ID
+	}
+
+	var (
+		events   []activityEvent
+		projects = make(map[int]string)
+	)
+	for _, eventType := range activityEventTypes(actions) {
+		opts := gitlab.ListContributionEventsOptions{
+			Action: eventType,
+			After:  after,
+			Before: before,
+		}
+		var (
+			list []*gitlab.ContributionEvent
+			err  error
+		)
+		if inProject {
+			list, err = lab.UserProjectEvents(*userID, projectID, opts, num)
+		} else {
+			list, err = lab.UserEvents(*userID, opts, num)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range list {
+			event := activityFromContributionEvent(e)
+			if e.ProjectID != 0 {
+				if _, ok := projects[e.ProjectID]; !ok {
+					projects[e.ProjectID] = strconv.Itoa(e.ProjectID)
+					if project, err := lab.GetProject(e.ProjectID); err == nil {
+						projects[e.ProjectID] = project.PathWithNamespace
+					}
+				}
+				event.Project = projects[e.ProjectID]
+			}
+			events = append(events, event)
+		}
+	}
+	return sortActivity(events, num), nil
+}
+
+// sortActivity orders events from the most recent, keeping num of them
+// when num isn't -1
+func sortActivity(events []activityEvent, num int) []activityEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.After(events[j].Time)
+	})
+	if num != -1 && len(events) > num {
+		events = events[:num]
+	}
+	return events
+}
+
+func activityFromProjectEvent(e *gitlab.ProjectEvent) activityEvent {
+	event := activityEvent{
+		Author: e.Author.Username,
+		Action: e.ActionName,
+		Target: activityTarget(e.TargetType, e.TargetIID),
+		Title:  e.TargetTitle,
+	}
+	if event.Author == "" {
+		event.Author = e.AuthorUsername
+	}
+	event.Time, _ = time.Parse(time.RFC3339, e.CreatedAt)
+
+	if e.PushData.Ref != "" {
+		event.Target = e.PushData.Ref
+		event.Title = activityPushTitle(e.PushData.CommitCount, e.PushData.CommitTitle)
+	} else if e.Note.ID != 0 {
+		event.Target = activityTarget(e.Note.NoteableType, e.Note.NoteableIID)
+	}
+	return event
+}
+
+func activityFromContributionEvent(e *gitlab.ContributionEvent) activityEvent {
+	event := activityEvent{
+		Author: e.Author.Username,
+		Action: e.ActionName,
+		Target: activityTarget(e.TargetType, e.TargetIID),
+		Title:  e.TargetTitle,
+	}
+	if event.Author == "" {
+		event.Author = e.AuthorUsername
+	}
+	if e.CreatedAt != nil {
+		event.Time = *e.CreatedAt
+	}
+
+	if e.PushData.Ref != "" {
+		event.Target = e.PushData.Ref
+		event.Title = activityPushTitle(e.PushData.CommitCount, e.PushData.CommitTitle)
+	} else if e.Note != nil {
+		event.Target = activityTarget(e.Note.NoteableType, e.Note.NoteableIID)
+	}
+	return event
+}
+
+func activityFromPipeline(p *gitlab.PipelineInfo) activityEvent {
+	event := activityEvent{
+		Action: "pipeline " + p.Status,
+		Target: p.Ref,
+		Title:  fmt.Sprintf("pipeline #%d", p.ID),
+	}
+	if p.UpdatedAt != nil {
+		event.Time = *p.UpdatedAt
+	}
+	return event
+}
+
+// activityTarget returns the reference of the target of an event in its
+// project, or its type when it has none
+func activityTarget(targetType string, iid int) string {
+	switch targetType {
+	case "Issue", "WorkItem":
+		return fmt.Sprintf("#%d", iid)
+	case "MergeRequest":
+		return fmt.Sprintf("!%d", iid)
+	case "Milestone":
+		return fmt.Sprintf("%%%d", iid)
+	case "":
+		return ""
+	}
+	return strings.ToLower(targetType)
+}
+
+func activityPushTitle(count int, title string) string {
+	if count == 0 {
+		return title
+	}
+	if count == 1 {
+		return "1 commit: " + title
+	}
+	return fmt.Sprintf("%d commits: %s", count, title)
+}
+
+// writeActivity writes the events aligned in columns, with their project
+// when they come from several projects
+func writeActivity(out io.Writer, events []activityEvent, withProject bool) {
+	w := tabwriter.NewWriter(out, 2, 4, 2, byte(' '), 0)
+	for _, e := range events {
+		columns := []string{e.Time.Local().Format("2006-01-02 15:04")}
+		if withProject {
+			columns = append(columns, e.Project)
+		}
+		columns = append(columns, e.Author, e.Action, e.Target, e.Title)
+		fmt.Fprintln(w, strings.Join(columns, "\t"))
+	}
+	w.Flush()
+}
+
+func init() {
+	activityCmd.Flags().String("user", "", "show the events of the given user")
+	activityCmd.Flags().StringSlice("action", []string{}, "show only the events with the given action(s)")
+	activityCmd.Flags().String("after", "", "show only the events after the given date (YYYY-MM-DD)")
+	activityCmd.Flags().String("before", "", "show only the events before the given date (YYYY-MM-DD)")
+	activityCmd.Flags().StringP("number", "n", "20", "number of events to show, 0 for all")
+	activityCmd.Flags().Bool("json", false, "print the events in JSON format")
+	RootCmd.AddCommand(activityCmd)
+
+	carapace.Gen(activityCmd).FlagCompletion(carapace.ActionMap{
+		"action": carapace.ActionValues(activityActions...).UniqueList(","),
+	})
+	carapace.Gen(activityCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
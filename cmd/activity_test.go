@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_activityFromProjectEvent(t *testing.T) {
+	e := &gitlab.ProjectEvent{
+		ActionName:  "accepted",
+		TargetType:  "MergeRequest",
+		TargetIID:   12,
+		TargetTitle: "Add activity",
+		CreatedAt:   "2024-07-01T10:00:00Z",
+	}
+	e.Author.Username = "alice"
+	assert.Equal(t, activityEvent{
+		Time:   time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC),
+		Author: "alice",
+		Action: "accepted",
+		Target: "!12",
+		Title:  "Add activity",
+	}, activityFromProjectEvent(e))
+
+	e = &gitlab.ProjectEvent{ActionName: "pushed to", AuthorUsername: "bob"}
+	e.PushData.Ref = "main"
+	e.PushData.CommitCount = 2
+	e.PushData.CommitTitle = "Fix typo"
+	event := activityFromProjectEvent(e)
+	assert.Equal(t, "bob", event.Author)
+	assert.Equal(t, "main", event.Target)
+	assert.Equal(t, "2 commits: Fix typo", event.Title)
+
+	e = &gitlab.ProjectEvent{ActionName: "commented on", TargetType: "Note", TargetTitle: "A bug"}
+	e.Note.ID = 5
+	e.Note.NoteableType = "Issue"
+	e.Note.NoteableIID = 3
+	assert.Equal(t, "#3", activityFromProjectEvent(e).Target)
+}
+
+func Test_activityFromContributionEvent(t *testing.T) {
+	created := time.Date(2024, 7, 2, 8, 30, 0, 0, time.UTC)
+	e := &gitlab.ContributionEvent{
+		ActionName:  "commented on",
+		TargetType:  "DiffNote",
+		TargetTitle: "Add activity",
+		CreatedAt:   &created,
+		Note:        &gitlab.Note{NoteableType: "MergeRequest", NoteableIID: 12},
+	}
+	e.Author.Username = "alice"
+	assert.Equal(t, activityEvent{
+		Time:   created,
+		Author: "alice",
+		Action: "commented on",
+		Target: "!12",
+		Title:  "Add activity",
+	}, activityFromContributionEvent(e))
+}
+
+func Test_activityFromPipeline(t *testing.T) {
+	updated := time.Date(2024, 7, 3, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, activityEvent{
+		Time:   updated,
+		Action: "pipeline failed",
+		Target: "main",
+		Title:  "pipeline #42",
+	}, activityFromPipeline(&gitlab.PipelineInfo{ID: 42, Ref: "main", Status: "failed", UpdatedAt: &updated}))
+}
+
+func Test_activityTarget(t *testing.T) {
+	assert.Equal(t, "#4", activityTarget("Issue", 4))
+	assert.Equal(t, "!4", activityTarget("MergeRequest", 4))
+	assert.Equal(t, "%4", activityTarget("Milestone", 4))
+	assert.Equal(t, "snippet", activityTarget("Snippet", 4))
+	assert.Equal(t, "", activityTarget("", 0))
+}
+
+func Test_activityEventTypes(t *testing.T) {
+	assert.Equal(t, []*gitlab.EventTypeValue{nil}, activityEventTypes(nil))
+
+	types := activityEventTypes([]string{"pushed", "pipeline", "merged"})
+	if assert.Len(t, types, 2) {
+		assert.Equal(t, gitlab.PushedEventType, *types[0])
+		assert.Equal(t, gitlab.MergedEventType, *types[1])
+	}
+	assert.Empty(t, activityEventTypes([]string{"pipeline"}))
+}
+
+func Test_sortActivity(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+	events := []activityEvent{
+		{Time: day(1), Action: "a"},
+		{Time: day(3), Action: "b"},
+		{Time: day(2), Action: "c"},
+	}
+	sorted := sortActivity(events, 2)
+	assert.Equal(t, []string{"b", "c"}, []string{sorted[0].Action, sorted[1].Action})
+	assert.Len(t, sortActivity(events, -1), 3)
+}
+
+func Test_writeActivity(t *testing.T) {
+	events := []activityEvent{
+		{Time: time.Date(2024, 7, 1, 10, 0, 0, 0, time.Local), Project: "group/a", Author: "alice", Action: "opened", Target: "#1", Title: "Bug"},
+		{Time: time.Date(2024, 7, 1, 9, 5, 0, 0, time.Local), Project: "group/bb", Author: "bob", Action: "pushed to", Target: "main", Title: "1 commit: Fix"},
+	}
+
+	var b bytes.Buffer
+	writeActivity(&b, events, false)
+	assert.Equal(t, ""+
+		"2024-07-01 10:00  alice  opened     #1    Bug\n"+
+		"2024-07-01 09:05  bob    pushed to  main  1 commit: Fix\n", b.String())
+
+	b.Reset()
+	writeActivity(&b, events, true)
+	assert.Equal(t, ""+
+		"2024-07-01 10:00  group/a   alice  opened     #1    Bug\n"+
+		"2024-07-01 09:05  group/bb  bob    pushed to  main  1 commit: Fix\n", b.String())
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+)
+
+var branchCmd = &cobra.Command{
+	Use:              "branch",
+	Short:            "Manage the remote branches of a project",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// branchAccessLevels maps the access level names accepted on the command
+// line to their values
+var branchAccessLevels = map[string]gitlab.AccessLevelValue{
+	"no-one":     gitlab.NoPermissions,
+	"developer":  gitlab.DeveloperPermissions,
+	"maintainer": gitlab.MaintainerPermissions,
+	"admin":      gitlab.AdminPermissions,
+}
+
+func parseBranchAccessLevel(level string) (gitlab.AccessLevelValue, error) {
+	value, ok := branchAccessLevels[strings.ToLower(level)]
+	if !ok {
+		return 0, errors.Errorf("invalid access level %q, use no-one, developer, maintainer or admin", level)
+	}
+	return value, nil
+}
+
+// branchProtection returns the protection rule matching a branch, if any.
+// Rules can be wildcards, where "*" matches any sequence of characters.
+func branchProtection(rules []*gitlab.ProtectedBranch, branch string) *gitlab.ProtectedBranch {
+	var match *gitlab.ProtectedBranch
+	for _, rule := range rules {
+		if rule.Name == branch {
+			return rule
+		}
+		if match != nil || !strings.Contains(rule.Name, "*") {
+			continue
+		}
+		pattern := strings.Replace(regexp.QuoteMeta(rule.Name), `\*`, ".*", -1)
+		if regexp.MustCompile("^" + pattern + "$").MatchString(branch) {
+			match = rule
+		}
+	}
+	return match
+}
+
+// branchAccessSummary describes who is allowed by access descriptions
+func branchAccessSummary(levels []*gitlab.BranchAccessDescription) string {
+	if len(levels) == 0 {
+		return "No one"
+	}
+	var allowed []string
+	for _, l := range levels {
+		allowed = append(allowed, l.AccessLevelDescription)
+	}
+	return strings.Join(allowed, ", ")
+}
+
+// branchProtectionSummary describes a protection rule on one line
+func branchProtectionSummary(rule *gitlab.ProtectedBranch) string {
+	summary := fmt.Sprintf("push: %s; merge: %s",
+		branchAccessSummary(rule.PushAccessLevels), branchAccessSummary(rule.MergeAccessLevels))
+	if rule.AllowForcePush {
+		summary += "; force push allowed"
+	}
+	if rule.CodeOwnerApprovalRequired {
+		summary += "; code owner approval required"
+	}
+	return summary
+}
+
+// branchCompletion completes the remote and a branch of that remote
+func branchCompletion(cmd *cobra.Command) {
+	carapace.Gen(cmd).PositionalCompletion(
+		action.Remotes(),
+		action.RemoteBranches(0),
+	)
+}
+
+func init() {
+	RootCmd.AddCommand(branchCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var branchCreateCmd = &cobra.Command{
+	Use:     "create [remote] <branch>",
+	Aliases: []string{"new"},
+	Short:   "Create a new remote branch",
+	Long: heredoc.Doc(`
+		Create a branch in the project from the given branch, tag or commit,
+		or from the default branch of the project.`),
+	Example: heredoc.Doc(`
+		lab branch create feature
+		lab branch create upstream release-1.0 --ref v1.0`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <branch>")
+		}
+
+		ref, err := cmd.Flags().GetString("ref")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if ref == "" {
+			project, err := lab.FindProject(rn)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ref = project.DefaultBranch
+		}
+
+		branch, err := lab.BranchCreate(rn, name, ref)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(branch.WebURL)
+	},
+}
+
+func init() {
+	branchCreateCmd.Flags().StringP("ref", "r", "", "branch, tag or commit to create the branch from (default branch by default)")
+	branchCmd.AddCommand(branchCreateCmd)
+
+	carapace.Gen(branchCreateCmd).FlagCompletion(carapace.ActionMap{
+		"ref": action.RemoteBranches(-1),
+	})
+	carapace.Gen(branchCreateCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var branchDeleteCmd = &cobra.Command{
+	Use:     "delete [remote] <branch>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a remote branch",
+	Example: heredoc.Doc(`
+		lab branch delete feature
+		lab branch delete upstream feature`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <branch>")
+		}
+
+		err = lab.BranchDelete(rn, name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Branch %s deleted\n", name)
+	},
+}
+
+func init() {
+	branchCmd.AddCommand(branchDeleteCmd)
+	branchCompletion(branchDeleteCmd)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var branchListCmd = &cobra.Command{
+	Use:     "list [remote] [search]",
+	Aliases: []string{"ls", "search"},
+	Short:   "List the branches of a project",
+	Long: heredoc.Doc(`
+		List the branches of a project with their last commit. Branches are
+		marked as default, merged into the default branch, stale when their
+		last commit is older than --stale-after days, and protected, in
+		which case the protection rules are shown.`),
+	Example: heredoc.Doc(`
+		lab branch list
+		lab branch list upstream feature
+		lab branch list --merged
+		lab branch list --stale --stale-after 30`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, search, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		merged, err := cmd.Flags().GetBool("merged")
+		if err != nil {
+			log.Fatal(err)
+		}
+		stale, err := cmd.Flags().GetBool("stale")
+		if err != nil {
+			log.Fatal(err)
+		}
+		staleAfter, err := cmd.Flags().GetString("stale-after")
+		if err != nil {
+			log.Fatal(err)
+		}
+		days, err := strconv.Atoi(staleAfter)
+		if err != nil || days <= 0 {
+			log.Fatalf("invalid number of days %q", staleAfter)
+		}
+		staleBefore := time.Now().AddDate(0, 0, -days)
+
+		opts := &gitlab.ListBranchesOptions{}
+		opts.PerPage = 100
+		if search != "" {
+			opts.Search = &search
+		}
+		branches, err := lab.BranchList(rn, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rules, err := lab.ProtectedBranchList(rn)
+		if err != nil {
+			// listing the protection rules requires the maintainer role
+			log.Debugln(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, byte(' '), 0)
+		for _, branch := range branches {
+			isStale := branchIsStale(branch, staleBefore)
+			if (merged && !branch.Merged) || (stale && !isStale) {
+				continue
+			}
+
+			sha, date, title := "", "", ""
+			if branch.Commit != nil {
+				sha, title = branch.Commit.ShortID, branch.Commit.Title
+				if branch.Commit.CommittedDate != nil {
+					date = branch.Commit.CommittedDate.Format("2006-01-02")
+				}
+			}
+
+			state := branchStates(branch, isStale)
+			if rule := branchProtection(rules, branch.Name); branch.Protected && rule != nil {
+				title += " (" + branchProtectionSummary(rule) + ")"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", branch.Name, strings.Join(state, ","), sha, date, title)
+		}
+		w.Flush()
+	},
+}
+
+// branchIsStale returns whether the last commit of branch is older than
+// staleBefore; the default branch is never stale
+func branchIsStale(branch *gitlab.Branch, staleBefore time.Time) bool {
+	if branch.Default || branch.Commit == nil || branch.Commit.CommittedDate == nil {
+		return false
+	}
+	return branch.Commit.CommittedDate.Before(staleBefore)
+}
+
+// branchStates returns the state markers of a branch
+func branchStates(branch *gitlab.Branch, stale bool) []string {
+	var states []string
+	if branch.Default {
+		states = append(states, "default")
+	}
+	if branch.Protected {
+		states = append(states, "protected")
+	}
+	if branch.Merged {
+		states = append(states, "merged")
+	}
+	if stale {
+		states = append(states, "stale")
+	}
+	return states
+}
+
+func init() {
+	branchListCmd.Flags().Bool("merged", false, "only list the branches merged into the default branch")
+	branchListCmd.Flags().Bool("stale", false, "only list the stale branches")
+	branchListCmd.Flags().String("stale-after", "90", "number of days without commits after which a branch is stale")
+	branchCmd.AddCommand(branchListCmd)
+	carapace.Gen(branchListCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
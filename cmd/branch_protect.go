@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var branchProtectCmd = &cobra.Command{
+	Use:   "protect [remote] <branch>",
+	Short: "Protect a branch",
+	Long: heredoc.Doc(`
+		Protect a branch, or all the branches matching a wildcard such as
+		"release/*", setting who is allowed to push and merge: no-one,
+		developer, maintainer or admin. An existing protection has to be
+		removed first to change its rules.`),
+	Example: heredoc.Doc(`
+		lab branch protect main
+		lab branch protect upstream 'release/*' --push no-one --merge developer
+		lab branch protect main --code-owner-approval`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <branch>")
+		}
+
+		push, err := cmd.Flags().GetString("push")
+		if err != nil {
+			log.Fatal(err)
+		}
+		merge, err := cmd.Flags().GetString("merge")
+		if err != nil {
+			log.Fatal(err)
+		}
+		forcePush, err := cmd.Flags().GetBool("allow-force-push")
+		if err != nil {
+			log.Fatal(err)
+		}
+		codeOwners, err := cmd.Flags().GetBool("code-owner-approval")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pushLevel, err := parseBranchAccessLevel(push)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mergeLevel, err := parseBranchAccessLevel(merge)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts := &gitlab.ProtectRepositoryBranchesOptions{
+			Name:             &name,
+			PushAccessLevel:  &pushLevel,
+			MergeAccessLevel: &mergeLevel,
+			AllowForcePush:   &forcePush,
+		}
+		// code owner approval is only available in GitLab Premium
+		if codeOwners {
+			opts.CodeOwnerApprovalRequired = &codeOwners
+		}
+
+		rule, err := lab.BranchProtect(rn, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Branch %s protected (%s)\n", rule.Name, branchProtectionSummary(rule))
+	},
+}
+
+func init() {
+	branchProtectCmd.Flags().String("push", "maintainer", "role allowed to push (no-one, developer, maintainer or admin)")
+	branchProtectCmd.Flags().String("merge", "maintainer", "role allowed to merge (no-one, developer, maintainer or admin)")
+	branchProtectCmd.Flags().Bool("allow-force-push", false, "allow force pushes by those allowed to push")
+	branchProtectCmd.Flags().Bool("code-owner-approval", false, "require the approval of code owners")
+	branchCmd.AddCommand(branchProtectCmd)
+
+	levels := carapace.ActionValues("no-one", "developer", "maintainer", "admin")
+	carapace.Gen(branchProtectCmd).FlagCompletion(carapace.ActionMap{
+		"push":  levels,
+		"merge": levels,
+	})
+	branchCompletion(branchProtectCmd)
+}
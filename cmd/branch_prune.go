@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// branchPrune is a branch to delete along with the MR it was merged with
+type branchPrune struct {
+	branch string
+	mr     int
+}
+
+var branchPruneMergedCmd = &cobra.Command{
+	Use:   "prune-merged [remote]",
+	Short: "Delete the remote branches of merged merge requests",
+	Long: heredoc.Doc(`
+		Delete the branches of the project that are the source branch of a
+		merged merge request. The default branch, protected branches and
+		the source branches of open merge requests are kept, as well as the
+		branches that received new commits after the merge, unless --force
+		is given.`),
+	Example: heredoc.Doc(`
+		lab branch prune-merged --dry-run
+		lab branch prune-merged upstream`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, _, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal(err)
+		}
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		project, err := lab.FindProject(rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts := &gitlab.ListBranchesOptions{}
+		opts.PerPage = 100
+		branches, err := lab.BranchList(rn, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		merged, opened := "merged", "opened"
+		mergedMRs, err := lab.MRList(rn, gitlab.ListProjectMergeRequestsOptions{State: &merged}, -1)
+		if err != nil {
+			log.Fatal(err)
+		}
+		openMRs, err := lab.MRList(rn, gitlab.ListProjectMergeRequestsOptions{State: &opened}, -1)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, p := range branchesToPrune(project.ID, branches, mergedMRs, openMRs, force) {
+			if dryRun {
+				fmt.Printf("Would delete %s (!%d)\n", p.branch, p.mr)
+				continue
+			}
+			err = lab.BranchDelete(rn, p.branch)
+			if err != nil {
+				log.Errorln(err)
+				continue
+			}
+			fmt.Printf("Deleted %s (!%d)\n", p.branch, p.mr)
+		}
+	},
+}
+
+// branchesToPrune returns the branches of the project that are the source
+// branch of a merged MR, leaving out the default and protected branches,
+// the ones used by open MRs and, unless force is set, the ones whose head
+// isn't the merged one
+func branchesToPrune(projectID int, branches []*gitlab.Branch, mergedMRs, openMRs []*gitlab.BasicMergeRequest, force bool) []branchPrune {
+	inUse := make(map[string]bool)
+	for _, mr := range openMRs {
+		if mr.SourceProjectID == projectID {
+			inUse[mr.SourceBranch] = true
+		}
+	}
+
+	mergedHeads := make(map[string][]*gitlab.BasicMergeRequest)
+	for _, mr := range mergedMRs {
+		if mr.SourceProjectID == projectID {
+			mergedHeads[mr.SourceBranch] = append(mergedHeads[mr.SourceBranch], mr)
+		}
+	}
+
+	var prune []branchPrune
+	for _, branch := range branches {
+		if branch.Default || branch.Protected || inUse[branch.Name] {
+			continue
+		}
+		for _, mr := range mergedHeads[branch.Name] {
+			if force || (branch.Commit != nil && branch.Commit.ID == mr.SHA) {
+				prune = append(prune, branchPrune{branch: branch.Name, mr: mr.IID})
+				break
+			}
+		}
+	}
+	return prune
+}
+
+func init() {
+	branchPruneMergedCmd.Flags().Bool("dry-run", false, "only print the branches that would be deleted")
+	branchPruneMergedCmd.Flags().BoolP("force", "f", false, "also delete the branches that received commits after the merge")
+	branchCmd.AddCommand(branchPruneMergedCmd)
+	carapace.Gen(branchPruneMergedCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_branchProtection(t *testing.T) {
+	rules := []*gitlab.ProtectedBranch{
+		{Name: "release/*"},
+		{Name: "main"},
+		{Name: "release/1.0"},
+	}
+	assert.Equal(t, rules[1], branchProtection(rules, "main"))
+	assert.Equal(t, rules[2], branchProtection(rules, "release/1.0"))
+	assert.Equal(t, rules[0], branchProtection(rules, "release/2.0"))
+	assert.Nil(t, branchProtection(rules, "feature"))
+	assert.Nil(t, branchProtection(rules, "my-release/1.0"))
+	assert.Nil(t, branchProtection(nil, "main"))
+}
+
+func Test_parseBranchAccessLevel(t *testing.T) {
+	level, err := parseBranchAccessLevel("Developer")
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.DeveloperPermissions, level)
+
+	level, err = parseBranchAccessLevel("no-one")
+	require.NoError(t, err)
+	assert.Equal(t, gitlab.NoPermissions, level)
+
+	_, err = parseBranchAccessLevel("guest")
+	assert.Error(t, err)
+}
+
+func Test_branchProtectionSummary(t *testing.T) {
+	rule := &gitlab.ProtectedBranch{
+		PushAccessLevels: []*gitlab.BranchAccessDescription{
+			{AccessLevelDescription: "Maintainers"},
+		},
+		MergeAccessLevels: []*gitlab.BranchAccessDescription{
+			{AccessLevelDescription: "Developers + Maintainers"},
+			{AccessLevelDescription: "jdoe"},
+		},
+		CodeOwnerApprovalRequired: true,
+	}
+	assert.Equal(t, "push: Maintainers; merge: Developers + Maintainers, jdoe; code owner approval required",
+		branchProtectionSummary(rule))
+
+	rule = &gitlab.ProtectedBranch{AllowForcePush: true}
+	assert.Equal(t, "push: No one; merge: No one; force push allowed", branchProtectionSummary(rule))
+}
+
+func Test_branchIsStale(t *testing.T) {
+	old := time.Now().AddDate(0, -6, 0)
+	recent := time.Now().AddDate(0, 0, -1)
+	staleBefore := time.Now().AddDate(0, 0, -90)
+
+	assert.True(t, branchIsStale(&gitlab.Branch{Commit: &gitlab.Commit{CommittedDate: &old}}, staleBefore))
+	assert.False(t, branchIsStale(&gitlab.Branch{Commit: &gitlab.Commit{CommittedDate: &recent}}, staleBefore))
+	assert.False(t, branchIsStale(&gitlab.Branch{Default: true, Commit: &gitlab.Commit{CommittedDate: &old}}, staleBefore))
+	assert.False(t, branchIsStale(&gitlab.Branch{}, staleBefore))
+}
+
+func Test_branchesToPrune(t *testing.T) {
+	branch := func(name, sha string) *gitlab.Branch {
+		return &gitlab.Branch{Name: name, Commit: &gitlab.Commit{ID: sha}}
+	}
+	mr := func(iid, project int, source, sha string) *gitlab.BasicMergeRequest {
+		return &gitlab.BasicMergeRequest{IID: iid, SourceProjectID: project, SourceBranch: source, SHA: sha}
+	}
+
+	branches := []*gitlab.Branch{
+		{Name: "main", Default: true, Commit: &gitlab.Commit{ID: "a"}},
+		{Name: "stable", Protected: true, Commit: &gitlab.Commit{ID: "b"}},
+		branch("feature", "c"),
+		branch("updated", "d"),
+		branch("reused", "e"),
+		branch("fork", "f"),
+		branch("unmerged", "g"),
+	}
+	merged := []*gitlab.BasicMergeRequest{
+		mr(1, 1, "main", "a"),
+		mr(2, 1, "stable", "b"),
+		mr(3, 1, "feature", "c"),
+		mr(4, 1, "updated", "old"),
+		mr(5, 1, "reused", "e"),
+		mr(6, 2, "fork", "f"),
+	}
+	open := []*gitlab.BasicMergeRequest{
+		mr(7, 1, "reused", "e"),
+		mr(8, 1, "unmerged", "g"),
+	}
+
+	assert.Equal(t, []branchPrune{{"feature", 3}}, branchesToPrune(1, branches, merged, open, false))
+	assert.Equal(t, []branchPrune{{"feature", 3}, {"updated", 4}}, branchesToPrune(1, branches, merged, open, true))
+}
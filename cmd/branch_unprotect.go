@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var branchUnprotectCmd = &cobra.Command{
+	Use:   "unprotect [remote] <branch>",
+	Short: "Remove the protection of a branch",
+	Long: heredoc.Doc(`
+		Remove the protection of a branch, or the protection of a wildcard
+		such as "release/*".`),
+	Example: heredoc.Doc(`
+		lab branch unprotect feature
+		lab branch unprotect upstream 'release/*'`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <branch>")
+		}
+
+		err = lab.BranchUnprotect(rn, name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Branch %s unprotected\n", name)
+	},
+}
+
+func init() {
+	branchCmd.AddCommand(branchUnprotectCmd)
+	branchCompletion(branchUnprotectCmd)
+}
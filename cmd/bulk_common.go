@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// bulkItem is an issue or a merge request selected by a bulk command
+type bulkItem struct {
+	IID       int
+	Title     string
+	UpdatedAt *time.Time
+	Assignees []int
+}
+
+// bulkEdit holds the changes applied by the bulk commands
+type bulkEdit struct {
+	addLabels    gitlab.LabelOptions
+	removeLabels gitlab.LabelOptions
+	assign       []int
+	unassign     []int
+	milestone    string
+	milestoneID  *int
+	close        bool
+	note         string
+}
+
+// bulkError is the failure of an edit of a bulk command
+type bulkError struct {
+	IID int
+	Err error
+}
+
+// bulkAddFlags adds the selection and edit flags shared by the bulk
+// commands to cmd
+func bulkAddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceP("label", "l", []string{}, "select by label")
+	cmd.Flags().String("milestone", "", "select by milestone/any/none")
+	cmd.Flags().String("author", "", "select by author")
+	cmd.Flags().String("assignee", "", "select by assignee/any/none")
+	cmd.Flags().StringP("state", "s", "opened", "select by state")
+	cmd.Flags().String("older-than", "", "select only the ones not updated for the given number of days")
+
+	cmd.Flags().StringSlice("add-label", []string{}, "add the given label(s)")
+	cmd.Flags().StringSlice("remove-label", []string{}, "remove the given label(s)")
+	cmd.Flags().StringSlice("assign", []string{}, "add the given assignee(s)")
+	cmd.Flags().StringSlice("unassign", []string{}, "remove the given assignee(s)")
+	cmd.Flags().String("set-milestone", "", "set the milestone, or remove it with \"none\"")
+	cmd.Flags().Bool("close", false, "close them")
+	cmd.Flags().StringP("note", "m", "", "add the given note")
+
+	cmd.Flags().Bool("dry-run", false, "show what would be changed without changing anything")
+	cmd.Flags().BoolP("yes", "y", false, "do not ask for confirmation")
+	cmd.Flags().String("concurrency", "4", "number of changes made in parallel")
+}
+
+// bulkEditFromFlags returns the changes requested by the flags of cmd for
+// the project rn
+func bulkEditFromFlags(cmd *cobra.Command, rn string) (bulkEdit, error) {
+	var edit bulkEdit
+
+	addLabels, err := cmd.Flags().GetStringSlice("add-label")
+	if err != nil {
+		return edit, err
+	}
+	edit.addLabels, err = mapLabelsAsLabelOptions(rn, addLabels)
+	if err != nil {
+		return edit, err
+	}
+	removeLabels, err := cmd.Flags().GetStringSlice("remove-label")
+	if err != nil {
+		return edit, err
+	}
+	edit.removeLabels, err = mapLabelsAsLabelOptions(rn, removeLabels)
+	if err != nil {
+		return edit, err
+	}
+
+	assign, err := cmd.Flags().GetStringSlice("assign")
+	if err != nil {
+		return edit, err
+	}
+	edit.assign = getUserIDs(assign)
+	unassign, err := cmd.Flags().GetStringSlice("unassign")
+	if err != nil {
+		return edit, err
+	}
+	edit.unassign = getUserIDs(unassign)
+
+	edit.milestone, err = cmd.Flags().GetString("set-milestone")
+	if err != nil {
+		return edit, err
+	}
+	if strings.ToLower(edit.milestone) == "none" {
+		edit.milestoneID = gitlab.Int(0)
+	} else if edit.milestone != "" {
+		milestone, err := lab.MilestoneGet(rn, edit.milestone)
+		if err != nil {
+			return edit, err
+		}
+		edit.milestone = milestone.Title
+		edit.milestoneID = &milestone.ID
+	}
+
+	edit.close, err = cmd.Flags().GetBool("close")
+	if err != nil {
+		return edit, err
+	}
+	edit.note, err = cmd.Flags().GetString("note")
+	if err != nil {
+		return edit, err
+	}
+	return edit, nil
+}
+
+// empty returns whether the edit changes nothing
+func (e bulkEdit) empty() bool {
+	return len(e.addLabels) == 0 && len(e.removeLabels) == 0 &&
+		len(e.assign) == 0 && len(e.unassign) == 0 &&
+		e.milestoneID == nil && !e.close && e.note == ""
+}
+
+// changesAssignees returns whether the edit adds or removes assignees
+func (e bulkEdit) changesAssignees() bool {
+	return len(e.assign) > 0 || len(e.unassign) > 0
+}
+
+// assignees returns the assignees of an item once edited
+func (e bulkEdit) assignees(current []int) []int {
+	ids := []int{}
+	for _, id := range append(append([]int{}, current...), e.assign...) {
+		if !containsInt(ids, id) && !containsInt(e.unassign, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		// removing all users needs []int{0}, as in getUpdateUsers
+		return []int{0}
+	}
+	return ids
+}
+
+// String describes the changes of the edit
+func (e bulkEdit) String() string {
+	var changes []string
+	if len(e.addLabels) > 0 {
+		changes = append(changes, "add labels "+strings.Join(e.addLabels, ", "))
+	}
+	if len(e.removeLabels) > 0 {
+		changes = append(changes, "remove labels "+strings.Join(e.removeLabels, ", "))
+	}
+	if len(e.assign) > 0 {
+		changes = append(changes, fmt.Sprintf("assign %d user(s)", len(e.assign)))
+	}
+	if len(e.unassign) > 0 {
+		changes = append(changes, fmt.Sprintf("unassign %d user(s)", len(e.unassign)))
+	}
+	if e.milestoneID != nil {
+		if *e.milestoneID == 0 {
+			changes = append(changes, "remove milestone")
+		} else {
+			changes = append(changes, "set milestone "+e.milestone)
+		}
+	}
+	if e.note != "" {
+		changes = append(changes, "add note")
+	}
+	if e.close {
+		changes = append(changes, "close")
+	}
+	return strings.Join(changes, ", ")
+}
+
+// containsInt returns true if a contains x
+func containsInt(a []int, x int) bool {
+	for _, y := range a {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkFilterOlderThan returns the items not updated since the given number
+// of days before now
+func bulkFilterOlderThan(items []bulkItem, days int, now time.Time) []bulkItem {
+	if days <= 0 {
+		return items
+	}
+	limit := now.AddDate(0, 0, -days)
+
+	var result []bulkItem
+	for _, item := range items {
+		if item.UpdatedAt != nil && item.UpdatedAt.Before(limit) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// bulkApply calls apply on each item, running at most concurrency calls in
+// parallel, and returns the failures ordered by IID
+func bulkApply(items []bulkItem, concurrency int, apply func(bulkItem) error) []bulkError {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []bulkError
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item bulkItem) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := apply(item)
+			if err != nil {
+				mu.Lock()
+				failures = append(failures, bulkError{item.IID, err})
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].IID < failures[j].IID
+	})
+	return failures
+}
+
+// runBulk previews the edit of the items, asks for confirmation and applies
+// it, printing a summary of the failures. The kind is "issue" or "merge
+// request" and prefix is the character of their references
+func runBulk(cmd *cobra.Command, items []bulkItem, edit bulkEdit, kind, prefix string, apply func(bulkItem) error) {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		log.Fatal(err)
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		log.Fatal(err)
+	}
+	parallel, err := cmd.Flags().GetString("concurrency")
+	if err != nil {
+		log.Fatal(err)
+	}
+	concurrency, err := strconv.Atoi(parallel)
+	if err != nil || concurrency < 1 {
+		log.Fatal("--concurrency must be a positive number")
+	}
+
+	if edit.empty() {
+		log.Fatal("no change requested")
+	}
+	if len(items) == 0 {
+		fmt.Printf("No %s selected\n", kind)
+		return
+	}
+
+	fmt.Printf("%s on %d %s(s):\n", edit, len(items), kind)
+	for _, item := range items {
+		fmt.Printf("%s%d %s\n", prefix, item.IID, item.Title)
+	}
+	if dryRun {
+		return
+	}
+	if !yes && !confirm("Apply the changes?") {
+		return
+	}
+
+	failures := bulkApply(items, concurrency, apply)
+	fmt.Printf("%d %s(s) updated, %d failed\n", len(items)-len(failures), kind, len(failures))
+	for _, f := range failures {
+		fmt.Fprintf(os.Stderr, "%s%d: %s\n", prefix, f.IID, f.Err)
+	}
+	if len(failures) > 0 {
+		os.Exit(1)
+	}
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// bulkAge returns the number of days of the --older-than flag of cmd
+func bulkAge(cmd *cobra.Command) (int, error) {
+	olderThan, err := cmd.Flags().GetString("older-than")
+	if err != nil || olderThan == "" {
+		return 0, err
+	}
+	days, err := strconv.Atoi(olderThan)
+	if err != nil || days < 0 {
+		return 0, errors.Errorf("invalid number of days %q", olderThan)
+	}
+	return days, nil
+}
+
+// bulkLabelCompletion completes the labels of the project of a bulk command
+func bulkLabelCompletion() carapace.Action {
+	return carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
+		project, _, err := parseArgsRemoteAndProject(c.Args)
+		if err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+		return action.Labels(project).Invoke(c).FilterParts()
+	})
+}
+
+// bulkMilestoneCompletion completes the milestones of the project of a bulk
+// command
+func bulkMilestoneCompletion() carapace.Action {
+	return carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+		project, _, err := parseArgsRemoteAndProject(c.Args)
+		if err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+		return action.Milestones(project, action.MilestoneOpts{Active: true})
+	})
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_bulkEditAssignees(t *testing.T) {
+	edit := bulkEdit{assign: []int{3, 4}, unassign: []int{1}}
+	current := []int{1, 2, 3}
+	assert.Equal(t, []int{2, 3, 4}, edit.assignees(current))
+	assert.Equal(t, []int{1, 2, 3}, current)
+	assert.Equal(t, []int{3, 4}, edit.assignees(nil))
+	assert.Equal(t, []int{0}, bulkEdit{unassign: []int{1}}.assignees([]int{1}))
+}
+
+func Test_bulkEditString(t *testing.T) {
+	assert.True(t, bulkEdit{}.empty())
+
+	edit := bulkEdit{
+		addLabels:    gitlab.LabelOptions{"bug"},
+		removeLabels: gitlab.LabelOptions{"new", "triage"},
+		assign:       []int{1},
+		milestone:    "1.0",
+		milestoneID:  gitlab.Int(7),
+		close:        true,
+		note:         "stale",
+	}
+	assert.False(t, edit.empty())
+	assert.Equal(t, "add labels bug, remove labels new, triage, assign 1 user(s), set milestone 1.0, add note, close", edit.String())
+	assert.Equal(t, "remove milestone", bulkEdit{milestoneID: gitlab.Int(0)}.String())
+}
+
+func Test_bulkFilterOlderThan(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -100)
+	recent := now.AddDate(0, 0, -10)
+	items := []bulkItem{
+		{IID: 1, UpdatedAt: &old},
+		{IID: 2, UpdatedAt: &recent},
+		{IID: 3},
+	}
+
+	assert.Equal(t, items, bulkFilterOlderThan(items, 0, now))
+	assert.Equal(t, []bulkItem{items[0]}, bulkFilterOlderThan(items, 30, now))
+	assert.Empty(t, bulkFilterOlderThan(items, 365, now))
+}
+
+func Test_bulkApply(t *testing.T) {
+	var items []bulkItem
+	for i := 1; i <= 20; i++ {
+		items = append(items, bulkItem{IID: i})
+	}
+
+	var running, maxRunning int32
+	failures := bulkApply(items, 3, func(item bulkItem) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if item.IID%5 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	var failed []int
+	for _, f := range failures {
+		failed = append(failed, f.IID)
+	}
+	assert.Equal(t, []int{5, 10, 15, 20}, failed)
+}
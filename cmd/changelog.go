@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// changelogOtherTitle is the category of MRs not matching any other one
+const changelogOtherTitle = "Other Changes"
+
+// changelogTmpl is the default Markdown template of the changelog
+var changelogTmpl = heredoc.Doc(`
+	## {{.To}}
+	{{range .Categories}}
+	### {{.Title}}
+	{{range .MergeRequests}}
+	- {{.Title}} (!{{.IID}}{{range .Issues}}, #{{.}}{{end}}) @{{.Author}}{{end}}
+	{{end}}`)
+
+// changelogCategoryConfig is a changelog category, as configured in the
+// changelog.categories entry of lab.toml
+type changelogCategoryConfig struct {
+	Title  string   `mapstructure:"title"`
+	Labels []string `mapstructure:"labels"`
+}
+
+type changelogMR struct {
+	IID      int        `json:"iid"`
+	Title    string     `json:"title"`
+	Author   string     `json:"author"`
+	URL      string     `json:"web_url"`
+	Labels   []string   `json:"labels"`
+	MergedAt *time.Time `json:"merged_at"`
+	Issues   []int      `json:"issues"`
+}
+
+type changelogCategory struct {
+	Title         string        `json:"title"`
+	MergeRequests []changelogMR `json:"merge_requests"`
+}
+
+type changelog struct {
+	Project    string              `json:"project"`
+	From       string              `json:"from"`
+	To         string              `json:"to"`
+	Categories []changelogCategory `json:"categories"`
+}
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [remote] <from>..<to>",
+	Short: "Generate release notes from the merge requests merged between two refs",
+	Long: heredoc.Doc(`
+		Generate release notes from the merge requests that introduced the
+		commits between two git refs, along with the issues they closed.
+		When <to> is omitted, HEAD is used.
+
+		Merge requests are grouped by the categories configured in lab.toml,
+		where each category lists the labels of the merge requests it holds.
+		A merge request goes to the first matching category; those matching
+		none are listed under "Other Changes":
+
+		  [[changelog.categories]]
+		    title = "Features"
+		    labels = ["feature"]
+		  [[changelog.categories]]
+		    title = "Bug Fixes"
+		    labels = ["bug", "regression"]
+
+		The output is Markdown unless --json is given. A custom Go template
+		can be used with --template; it receives the project, .From, .To and
+		.Categories, each category having a .Title and .MergeRequests with
+		.IID, .Title, .Author, .URL, .Labels, .MergedAt and .Issues fields.`),
+	Example: heredoc.Doc(`
+		lab changelog v1.0..v1.1
+		lab changelog upstream v1.0..
+		lab changelog v1.0..HEAD --json
+		lab changelog v1.0..v1.1 --template release.tmpl`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, refs, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		from, to := parseRefRange(refs)
+		if from == "" {
+			log.Fatalf("invalid range %s, use <from>..<to>", refs)
+		}
+
+		jsonOutput, err := cmd.Flags().GetBool("json")
+		if err != nil {
+			log.Fatal(err)
+		}
+		tmplFile, err := cmd.Flags().GetString("template")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var categories []changelogCategoryConfig
+		if config := getMainConfig(); config != nil {
+			err = config.UnmarshalKey("changelog.categories", &categories)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		commits, err := git.FirstParentCommits(from, to)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var mrs []changelogMR
+		seen := make(map[int]bool)
+		for _, sha := range commits {
+			commitMRs, err := lab.MRsByCommit(rn, sha)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, mr := range commitMRs {
+				if mr.State != "merged" || seen[mr.IID] {
+					continue
+				}
+				seen[mr.IID] = true
+
+				issues, err := lab.ListIssuesClosedOnMerge(rn, mr.IID)
+				if err != nil {
+					log.Fatal(err)
+				}
+				mrs = append(mrs, changelogMR{
+					IID:      mr.IID,
+					Title:    mr.Title,
+					Author:   mr.Author.Username,
+					URL:      mr.WebURL,
+					Labels:   mr.Labels,
+					MergedAt: mr.MergedAt,
+					Issues:   issues,
+				})
+			}
+		}
+
+		cl := changelog{
+			Project:    rn,
+			From:       from,
+			To:         to,
+			Categories: categorizeChangelog(mrs, categories),
+		}
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(cl, "", "  ")
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(out))
+			return
+		}
+
+		tmpl := changelogTmpl
+		if tmplFile != "" {
+			content, err := ioutil.ReadFile(tmplFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			tmpl = string(content)
+		}
+		out, err := renderChangelog(tmpl, cl)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(out)
+	},
+}
+
+// parseRefRange splits a <from>..<to> range, defaulting <to> to HEAD
+func parseRefRange(refs string) (string, string) {
+	from, to := refs, "HEAD"
+	if i := strings.Index(refs, ".."); i >= 0 {
+		from, to = refs[:i], refs[i+2:]
+		if to == "" {
+			to = "HEAD"
+		}
+	}
+	return from, to
+}
+
+// categorizeChangelog groups the MRs in the configured categories, in the
+// configured order, leaving out empty categories
+func categorizeChangelog(mrs []changelogMR, categories []changelogCategoryConfig) []changelogCategory {
+	groups := make([]changelogCategory, len(categories)+1)
+	for i, c := range categories {
+		groups[i].Title = c.Title
+	}
+	groups[len(categories)].Title = changelogOtherTitle
+
+	for _, mr := range mrs {
+		i := 0
+		for ; i < len(categories); i++ {
+			if changelogHasLabel(mr.Labels, categories[i].Labels) {
+				break
+			}
+		}
+		groups[i].MergeRequests = append(groups[i].MergeRequests, mr)
+	}
+
+	result := []changelogCategory{}
+	for _, g := range groups {
+		if len(g.MergeRequests) > 0 {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// changelogHasLabel returns whether labels contains any of wanted
+func changelogHasLabel(labels, wanted []string) bool {
+	for _, l := range labels {
+		for _, w := range wanted {
+			if strings.EqualFold(l, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func renderChangelog(tmpl string, cl changelog) (string, error) {
+	t, err := template.New("changelog").Funcs(template.FuncMap{
+		"join": strings.Join,
+	}).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, cl)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func init() {
+	changelogCmd.Flags().Bool("json", false, "print the changelog in JSON format")
+	changelogCmd.Flags().StringP("template", "t", "", "render the changelog with the Go template in the given file")
+	RootCmd.AddCommand(changelogCmd)
+	carapace.Gen(changelogCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
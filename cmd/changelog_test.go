@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseRefRange(t *testing.T) {
+	tests := []struct {
+		refs string
+		from string
+		to   string
+	}{
+		{"v1.0..v1.1", "v1.0", "v1.1"},
+		{"v1.0..", "v1.0", "HEAD"},
+		{"v1.0", "v1.0", "HEAD"},
+		{"..v1.1", "", "v1.1"},
+	}
+	for _, test := range tests {
+		t.Run(test.refs, func(t *testing.T) {
+			from, to := parseRefRange(test.refs)
+			assert.Equal(t, test.from, from)
+			assert.Equal(t, test.to, to)
+		})
+	}
+}
+
+func Test_categorizeChangelog(t *testing.T) {
+	categories := []changelogCategoryConfig{
+		{Title: "Features", Labels: []string{"feature"}},
+		{Title: "Bug Fixes", Labels: []string{"bug", "regression"}},
+		{Title: "Documentation", Labels: []string{"docs"}},
+	}
+	mrs := []changelogMR{
+		{IID: 1, Labels: []string{"Bug"}},
+		{IID: 2, Labels: []string{"feature", "bug"}},
+		{IID: 3},
+		{IID: 4, Labels: []string{"regression"}},
+	}
+
+	groups := categorizeChangelog(mrs, categories)
+	require.Len(t, groups, 3)
+	assert.Equal(t, "Features", groups[0].Title)
+	assert.Equal(t, []changelogMR{mrs[1]}, groups[0].MergeRequests)
+	assert.Equal(t, "Bug Fixes", groups[1].Title)
+	assert.Equal(t, []changelogMR{mrs[0], mrs[3]}, groups[1].MergeRequests)
+	assert.Equal(t, changelogOtherTitle, groups[2].Title)
+	assert.Equal(t, []changelogMR{mrs[2]}, groups[2].MergeRequests)
+
+	assert.Empty(t, categorizeChangelog(nil, categories))
+}
+
+func Test_renderChangelog(t *testing.T) {
+	cl := changelog{
+		Project: "zaquestion/test",
+		From:    "v1.0",
+		To:      "v1.1",
+		Categories: []changelogCategory{
+			{Title: "Features", MergeRequests: []changelogMR{
+				{IID: 3, Title: "Add changelog", Author: "alice", Issues: []int{1, 2}},
+			}},
+			{Title: "Other Changes", MergeRequests: []changelogMR{
+				{IID: 5, Title: "Update README", Author: "bob", Labels: []string{"a", "b"}},
+			}},
+		},
+	}
+
+	out, err := renderChangelog(changelogTmpl, cl)
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		## v1.1
+
+		### Features
+
+		- Add changelog (!3, #1, #2) @alice
+
+		### Other Changes
+
+		- Update README (!5) @bob
+		`), out)
+
+	out, err = renderChangelog(`{{range .Categories}}{{range .MergeRequests}}{{join .Labels ","}}{{end}}{{end}}`, cl)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b", out)
+
+	_, err = renderChangelog("{{.Unknown}}", cl)
+	assert.Error(t, err)
+}
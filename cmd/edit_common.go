@@ -10,6 +10,7 @@ import (
 	"text/template"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
 	"github.com/zaquestion/lab/internal/git"
 )
 
@@ -44,6 +45,17 @@ func getUpdateUsers(currentUsers []string, users []string, remove []string) ([]i
 // current issue title and description and various flags from the command
 // line
 func editDescription(title string, body string, msgs []string, filename string) (string, string, error) {
+	title, body, _, err := editDescriptionFields(title, body, msgs, filename, false)
+	return title, body, err
+}
+
+// editIssueDescription is editDescription for issues, whose file or edited
+// text can start with a frontmatter setting their fields
+func editIssueDescription(title string, body string, msgs []string, filename string) (string, string, map[string]string, error) {
+	return editDescriptionFields(title, body, msgs, filename, true)
+}
+
+func editDescriptionFields(title string, body string, msgs []string, filename string, frontmatter bool) (string, string, map[string]string, error) {
 	if len(msgs) > 0 {
 		title = msgs[0]
 
@@ -51,36 +63,61 @@ func editDescription(title string, body string, msgs []string, filename string)
 			body = strings.Join(msgs[1:], "\n\n")
 		}
 
-		return title, body, nil
+		return title, body, nil, nil
 	}
 
 	if filename != "" {
-		var lines []string
+		var (
+			lines  []string
+			fields map[string]string
+		)
 
 		content, err := ioutil.ReadFile(filename)
 		if err != nil {
-			return "", "", err
+			return "", "", nil, err
+		}
+		text := string(content)
+		if frontmatter {
+			fields, text, err = parseFrontmatter(text)
+			if err != nil {
+				return "", "", nil, err
+			}
 		}
-		lines = strings.Split(string(content), "\n")
+		lines = strings.Split(text, "\n")
 
 		title = lines[0]
 		body = strings.Join(lines[1:], "\n")
 
-		return title, body, nil
+		return title, body, fields, nil
 	}
 
 	text, err := editText(title, body)
 	if err != nil {
-		return "", "", err
+		return "", "", nil, err
 	}
 
-	title, body, err = git.Edit("EDIT", text)
+	return editDescriptionText("EDIT", text, frontmatter)
+}
+
+// editDescriptionText opens text in the editor and returns the title,
+// description and, when asked for, frontmatter it was edited into
+func editDescriptionText(filePrefix, text string, frontmatter bool) (string, string, map[string]string, error) {
+	contents, err := git.EditFile(filePrefix, text)
 	if err != nil {
 		_, f, l, _ := runtime.Caller(0)
 		log.Fatal(f+":"+strconv.Itoa(l)+" ", err)
 	}
+	text = strings.TrimSpace(contents)
 
-	return title, body, nil
+	var fields map[string]string
+	if frontmatter {
+		fields, text, err = parseFrontmatter(text)
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
+	title, body, err := git.ParseTitleBody(strings.TrimSpace(text))
+	return title, body, fields, err
 }
 
 // editText places the text title and body in a specific template following Git
@@ -113,3 +150,39 @@ func editText(title string, body string) (string, error) {
 
 	return b.String(), nil
 }
+
+// parseFrontmatter splits text in the "key: value" lines of its frontmatter,
+// delimited by "---" lines at its start, and the rest of the text. Keys are
+// lowercased, with dashes replaced by underscores.
+func parseFrontmatter(text string) (map[string]string, string, error) {
+	lines := strings.Split(text, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, text, nil
+	}
+
+	end := 0
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	// no closing delimiter, so no frontmatter
+	if end == 0 {
+		return nil, text, nil
+	}
+
+	fields := make(map[string]string)
+	for i, line := range lines[1:end] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, "", errors.Errorf("invalid frontmatter line %d %q, use \"key: value\"", i+2, line)
+		}
+		key := strings.Replace(strings.ToLower(strings.TrimSpace(parts[0])), "-", "_", -1)
+		fields[key] = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+	}
+	return fields, strings.Join(lines[end+1:], "\n"), nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -112,3 +113,46 @@ old body
 # is the title and the rest is the description.`, text)
 
 }
+
+func Test_parseFrontmatter(t *testing.T) {
+	fields, text, err := parseFrontmatter("---\nWeight: 3\ndue-date: \"2024-07-01\"\n\n---\nTitle\n\nBody")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"weight": "3", "due_date": "2024-07-01"}, fields)
+	assert.Equal(t, "Title\n\nBody", text)
+
+	fields, text, err = parseFrontmatter("Title\n---\nweight: 3\n---")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+	assert.Equal(t, "Title\n---\nweight: 3\n---", text)
+
+	fields, text, err = parseFrontmatter("---\nweight: 3\nTitle")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+	assert.Equal(t, "---\nweight: 3\nTitle", text)
+
+	// a text starting with a horizontal rule isn't silently dropped
+	_, _, err = parseFrontmatter("---\nSome text\n---\nTitle")
+	assert.EqualError(t, err, `invalid frontmatter line 2 "Some text", use "key: value"`)
+}
+
+func Test_editDescriptionFrontmatter(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "issue.md")
+	require.NoError(t, os.WriteFile(filename, []byte("---\nweight: 3\n---\nnew title\n\nnew body"), 0644))
+
+	title, body, fields, err := editIssueDescription("old title", "old body", nil, filename)
+	require.NoError(t, err)
+	assert.Equal(t, "new title", title)
+	assert.Equal(t, "\nnew body", body)
+	assert.Equal(t, map[string]string{"weight": "3"}, fields)
+}
+
+func Test_editDescriptionKeepsFrontmatter(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "release.md")
+	require.NoError(t, os.WriteFile(filename, []byte("---\nSome notes\n---\nMore notes"), 0644))
+
+	// only issues have a frontmatter, the text of the others is kept as is
+	title, body, err := editDescription("", "", nil, filename)
+	require.NoError(t, err)
+	assert.Equal(t, "---", title)
+	assert.Equal(t, "Some notes\n---\nMore notes", body)
+}
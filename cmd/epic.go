@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var epicCmd = &cobra.Command{
+	Use:   "epic",
+	Short: "Work with the epics of a group",
+	Long: heredoc.Doc(`
+		Work with the epics of the group given with --group, or of the group
+		owning the project of the default remote. Epics are only available
+		in GitLab Premium.`),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// epicGroup returns the group of the epics given with --group, defaulting
+// to the group of the current project
+func epicGroup(cmd *cobra.Command) *gitlab.Group {
+	path, err := cmd.Flags().GetString("group")
+	if err != nil {
+		log.Fatal(err)
+	}
+	group, err := resolveGroup(path, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return group
+}
+
+// parseEpicID parses an epic id, with or without the & prefix of the
+// epic references
+func parseEpicID(s string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(s, "&"))
+	if err != nil || id <= 0 {
+		return 0, errors.Errorf("invalid epic id %q", s)
+	}
+	return id, nil
+}
+
+func init() {
+	epicCmd.PersistentFlags().StringP("group", "g", "", "group of the epics, instead of the group of the current project")
+	RootCmd.AddCommand(epicCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var epicAddIssueCmd = &cobra.Command{
+	Use:   "add-issue <id> <issue>...",
+	Short: "Add issues to an epic",
+	Long: heredoc.Doc(`
+		Add issues to an epic, given as issue ids of the project of the
+		default remote or as full references to issues of other projects of
+		the group, like group/project#12. An issue already in another epic
+		is moved to this one.`),
+	Example: heredoc.Doc(`
+		lab epic add-issue 3 12
+		lab epic add-issue 3 12 group/other-project#4`),
+	Args:             cobra.MinimumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := parseEpicID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		group := epicGroup(cmd)
+
+		rn, err := git.PathWithNamespace(defaultRemote)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, ref := range args[1:] {
+			project, issueID, err := parseIssueReference(ref, rn)
+			if err != nil {
+				log.Fatal(err)
+			}
+			issue, err := lab.IssueGet(project, issueID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = lab.EpicAddIssue(group.ID, id, issue.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Issue %s#%d added to epic &%d\n", project, issueID, id)
+		}
+	},
+}
+
+func init() {
+	epicCmd.AddCommand(epicAddIssueCmd)
+}
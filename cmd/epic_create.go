@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var epicCreateCmd = &cobra.Command{
+	Use:     "create",
+	Aliases: []string{"new"},
+	Short:   "Open an epic",
+	Example: heredoc.Doc(`
+		lab epic create
+		lab epic create -m "epic title" -m "epic description"
+		lab epic create --group my-group -m "roadmap" -l planning
+		lab epic create -m "child epic" --parent 3 --confidential
+		lab epic create -F epic.md`),
+	Args:             cobra.NoArgs,
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		msgs, err := cmd.Flags().GetStringArray("message")
+		if err != nil {
+			log.Fatal(err)
+		}
+		filename, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatal(err)
+		}
+		labels, err := cmd.Flags().GetStringSlice("label")
+		if err != nil {
+			log.Fatal(err)
+		}
+		confidential, err := cmd.Flags().GetBool("confidential")
+		if err != nil {
+			log.Fatal(err)
+		}
+		parent, err := cmd.Flags().GetString("parent")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		group := epicGroup(cmd)
+
+		title, body, err := editDescription("", "", msgs, filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if title == "" {
+			log.Fatal("aborting epic due to empty epic title")
+		}
+
+		opts := &gitlab.CreateEpicOptions{
+			Title:        &title,
+			Description:  &body,
+			Labels:       (*gitlab.LabelOptions)(&labels),
+			Confidential: &confidential,
+		}
+		if parent != "" {
+			parentID, err := epicParentID(group.ID, parent)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opts.ParentID = &parentID
+		}
+
+		epic, err := lab.EpicCreate(group.ID, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(epic.WebURL)
+	},
+}
+
+// epicParentID returns the global ID of the parent epic given by its id in
+// the group, as expected by the API
+func epicParentID(groupID int, parent string) (int, error) {
+	id, err := parseEpicID(parent)
+	if err != nil {
+		return 0, err
+	}
+	epic, err := lab.EpicGet(groupID, id)
+	if err != nil {
+		return 0, err
+	}
+	return epic.ID, nil
+}
+
+func init() {
+	epicCreateCmd.Flags().StringArrayP("message", "m", []string{}, "use the given <msg>; multiple -m are concatenated as separate paragraphs")
+	epicCreateCmd.Flags().StringP("file", "F", "", "use the given file as the title and description of the epic")
+	epicCreateCmd.Flags().StringSliceP("label", "l", []string{}, "set the given label(s) on the created epic")
+	epicCreateCmd.Flags().Bool("confidential", false, "make the epic confidential")
+	epicCreateCmd.Flags().String("parent", "", "add the epic as a child of the given epic")
+	epicCmd.AddCommand(epicCreateCmd)
+
+	carapace.Gen(epicCreateCmd).FlagCompletion(carapace.ActionMap{
+		"file": carapace.ActionFiles(),
+	})
+}
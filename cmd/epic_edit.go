@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var epicEditCmd = &cobra.Command{
+	Use:     "edit <id>",
+	Aliases: []string{"update"},
+	Short:   "Edit or update an epic",
+	Example: heredoc.Doc(`
+		lab epic edit 3
+		lab epic edit 3 -m "new title" -m "new description"
+		lab epic edit 3 -l new_label --unlabel old_label
+		lab epic edit 3 --parent 1 --confidential=false
+		lab epic edit --group my-group 3 --close`),
+	Args:             cobra.ExactArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := parseEpicID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		group := epicGroup(cmd)
+
+		epic, err := lab.EpicGet(group.ID, id)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		msgs, _ := cmd.Flags().GetStringArray("message")
+		filename, _ := cmd.Flags().GetString("file")
+		addLabels, _ := cmd.Flags().GetStringSlice("label")
+		rmLabels, _ := cmd.Flags().GetStringSlice("unlabel")
+		parent, _ := cmd.Flags().GetString("parent")
+		closeEpic, _ := cmd.Flags().GetBool("close")
+		reopenEpic, _ := cmd.Flags().GetBool("reopen")
+		if closeEpic && reopenEpic {
+			log.Fatal("--close and --reopen can't be used together")
+		}
+
+		opts := &gitlab.UpdateEpicOptions{}
+		changed := false
+
+		// Like issues, the editor is only opened when no other flag is
+		// given, or when the title or description is given
+		if len(msgs) > 0 || filename != "" || cmd.Flags().NFlag() == 0 ||
+			(cmd.Flags().NFlag() == 1 && cmd.Flags().Changed("group")) {
+			title, body, err := editDescription(epic.Title, epic.Description, msgs, filename)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if title == "" {
+				log.Fatal("aborting: empty epic title")
+			}
+			if title != epic.Title || body != epic.Description {
+				opts.Title = &title
+				opts.Description = &body
+				changed = true
+			}
+		}
+
+		if len(addLabels) > 0 {
+			opts.AddLabels = (*gitlab.LabelOptions)(&addLabels)
+			changed = true
+		}
+		if len(rmLabels) > 0 {
+			opts.RemoveLabels = (*gitlab.LabelOptions)(&rmLabels)
+			changed = true
+		}
+		if cmd.Flags().Changed("confidential") {
+			confidential, _ := cmd.Flags().GetBool("confidential")
+			opts.Confidential = &confidential
+			changed = true
+		}
+		if parent != "" {
+			parentID, err := epicParentID(group.ID, parent)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opts.ParentID = &parentID
+			changed = true
+		}
+		if closeEpic {
+			opts.StateEvent = gitlab.String("close")
+			changed = true
+		} else if reopenEpic {
+			opts.StateEvent = gitlab.String("reopen")
+			changed = true
+		}
+
+		if !changed {
+			log.Fatal("aborting: no changes")
+		}
+
+		epic, err = lab.EpicUpdate(group.ID, id, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(epic.WebURL)
+	},
+}
+
+func init() {
+	epicEditCmd.Flags().StringArrayP("message", "m", []string{}, "use the given <msg>; multiple -m are concatenated as separate paragraphs")
+	epicEditCmd.Flags().StringP("file", "F", "", "use the given file as the title and description of the epic")
+	epicEditCmd.Flags().StringSliceP("label", "l", []string{}, "add the given label(s) to the epic")
+	epicEditCmd.Flags().StringSlice("unlabel", []string{}, "remove the given label(s) from the epic")
+	epicEditCmd.Flags().Bool("confidential", false, "make the epic confidential, or not with --confidential=false")
+	epicEditCmd.Flags().String("parent", "", "move the epic under the given epic")
+	epicEditCmd.Flags().Bool("close", false, "close the epic")
+	epicEditCmd.Flags().Bool("reopen", false, "reopen the epic")
+	epicEditCmd.Flags().SortFlags = false
+	epicCmd.AddCommand(epicEditCmd)
+
+	carapace.Gen(epicEditCmd).FlagCompletion(carapace.ActionMap{
+		"file": carapace.ActionFiles(),
+	})
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var epicListCmd = &cobra.Command{
+	Use:     "list [search]",
+	Aliases: []string{"ls", "search"},
+	Short:   "List epics",
+	Example: heredoc.Doc(`
+		lab epic list
+		lab epic list "search terms"
+		lab epic list --group my-group -l roadmap
+		lab epic list --author janedoe --state all -n 20`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		group := epicGroup(cmd)
+
+		state, _ := cmd.Flags().GetString("state")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		author, _ := cmd.Flags().GetString("author")
+		numRet, _ := cmd.Flags().GetString("number")
+		all, _ := cmd.Flags().GetBool("all")
+
+		num, err := strconv.Atoi(numRet)
+		if all || err != nil {
+			num = -1
+		}
+
+		opts := gitlab.ListGroupEpicsOptions{
+			State: &state,
+		}
+		if len(labels) > 0 {
+			opts.Labels = (*gitlab.LabelOptions)(&labels)
+		}
+		if author != "" {
+			opts.AuthorID = getUserID(author)
+			if opts.AuthorID == nil {
+				log.Fatalf("%s user not found\n", author)
+			}
+		}
+		if len(args) > 0 {
+			opts.Search = &args[0]
+		}
+
+		epics, err := lab.EpicList(group.ID, opts, num)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pager := newPager(cmd.Flags())
+		defer pager.Close()
+
+		for _, epic := range epics {
+			fmt.Printf("&%d %s\n", epic.IID, epic.Title)
+		}
+	},
+}
+
+func init() {
+	epicListCmd.Flags().StringSliceP("label", "l", []string{}, "filter epics by label")
+	epicListCmd.Flags().StringP("state", "s", "opened", "filter epics by state (all/opened/closed)")
+	epicListCmd.Flags().String("author", "", "filter epics by author")
+	epicListCmd.Flags().StringP("number", "n", "10", "number of epics to return")
+	epicListCmd.Flags().BoolP("all", "a", false, "list all epics of the group")
+	epicCmd.AddCommand(epicListCmd)
+
+	carapace.Gen(epicListCmd).FlagCompletion(carapace.ActionMap{
+		"state": carapace.ActionValues("all", "opened", "closed"),
+	})
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/charmbracelet/glamour"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var epicShowCmd = &cobra.Command{
+	Use:     "show <id>",
+	Aliases: []string{"get"},
+	Short:   "Describe an epic and list its issues",
+	Example: heredoc.Doc(`
+		lab epic show 3
+		lab epic show --group my-group '&3'
+		lab epic show 3 --no-markdown`),
+	Args:             cobra.ExactArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := parseEpicID(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		group := epicGroup(cmd)
+
+		epic, err := lab.EpicGet(group.ID, id)
+		if err != nil {
+			log.Fatal(err)
+		}
+		issues, err := lab.EpicIssues(group.ID, id)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		noMarkdown, _ := cmd.Flags().GetBool("no-markdown")
+		if !noMarkdown && isOutputTerminal() {
+			r, err := getTermRenderer(glamour.WithAutoStyle())
+			if err != nil {
+				log.Fatal(err)
+			}
+			epic.Description, _ = r.Render(epic.Description)
+		}
+
+		pager := newPager(cmd.Flags())
+		defer pager.Close()
+
+		printEpic(epic, group.FullPath, issues)
+	},
+}
+
+func printEpic(epic *gitlab.Epic, group string, issues []*gitlab.Issue) {
+	state := map[string]string{
+		"opened": "Open",
+		"closed": "Closed",
+	}[epic.State]
+	author := ""
+	if epic.Author != nil {
+		author = epic.Author.Username
+	}
+	startDate := "None"
+	if epic.StartDate != nil {
+		startDate = time.Time(*epic.StartDate).Format("2006-01-02")
+	}
+	dueDate := "None"
+	if epic.DueDate != nil {
+		dueDate = time.Time(*epic.DueDate).Format("2006-01-02")
+	}
+	confidential := "No"
+	if epic.Confidential {
+		confidential = "Yes"
+	}
+
+	fmt.Printf(
+		heredoc.Doc(`&%d %s
+			===================================
+			%s
+			-----------------------------------
+			Group: %s
+			Status: %s
+			Author: %s
+			Start Date: %s
+			Due Date: %s
+			Labels: %s
+			Confidential: %s
+			WebURL: %s
+		`),
+		epic.IID, epic.Title, epic.Description, group, state, author,
+		startDate, dueDate, strings.Join(epic.Labels, ", "), confidential,
+		epic.WebURL,
+	)
+
+	fmt.Printf("\nIssues (%d):\n", len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  %s %s (%s)\n", issueFullReference(issue), issue.Title, issue.State)
+	}
+}
+
+func init() {
+	epicShowCmd.Flags().BoolP("no-markdown", "M", false, "don't use markdown renderer to print the epic description")
+	epicCmd.AddCommand(epicShowCmd)
+}
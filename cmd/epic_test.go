@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseEpicID(t *testing.T) {
+	id, err := parseEpicID("3")
+	require.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	id, err = parseEpicID("&42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	for _, s := range []string{"", "&", "epic", "#3", "0", "-1"} {
+		_, err = parseEpicID(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// groupCurrent is the value of the --group flags given without a group,
+// selecting the group of the project of the default remote
+const groupCurrent = "."
+
+var groupCmd = &cobra.Command{
+	Use:              "group",
+	Short:            "Perform group level operations on GitLab",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var groupShowCmd = &cobra.Command{
+	Use:   "show [group]",
+	Short: "Show the details of a group",
+	Long: heredoc.Doc(`
+		Show the details of the given group, or of the group owning the
+		project of the default remote.`),
+	Example: heredoc.Doc(`
+		lab group show
+		lab group show my-group/sub-group`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+		group, err := resolveGroup(path, "")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf(heredoc.Doc(`
+			%s
+			-----------------------------------
+			Path: %s
+			Visibility: %s
+			WebURL: %s
+			`),
+			group.FullName, group.FullPath, group.Visibility, group.WebURL)
+		if group.Description != "" {
+			fmt.Printf("\n%s\n", group.Description)
+		}
+	},
+}
+
+// resolveGroup returns the group with the given path, or the group owning
+// project when path is empty or groupCurrent, the project of the default
+// remote being used when project is empty
+func resolveGroup(path, project string) (*gitlab.Group, error) {
+	if path == "" || path == groupCurrent {
+		if project == "" {
+			var err error
+			project, err = git.PathWithNamespace(defaultRemote)
+			if err != nil {
+				return nil, err
+			}
+		}
+		path = projectNamespace(project)
+		if path == "" {
+			return nil, errors.Errorf("project %s is not part of a group", project)
+		}
+	}
+	return lab.GroupSearch(path)
+}
+
+// projectNamespace returns the namespace part of a project path
+func projectNamespace(project string) string {
+	i := strings.LastIndex(project, "/")
+	if i < 0 {
+		return ""
+	}
+	return project[:i]
+}
+
+// issueFullReference returns the full reference of an issue, as the issues
+// listed from a group belong to several projects
+func issueFullReference(issue *gitlab.Issue) string {
+	if issue.References != nil && issue.References.Full != "" {
+		return issue.References.Full
+	}
+	return "#" + strconv.Itoa(issue.IID)
+}
+
+// mrFullReference returns the full reference of a merge request, as the
+// merge requests listed from a group belong to several projects
+func mrFullReference(mr *gitlab.BasicMergeRequest) string {
+	if mr.References != nil && mr.References.Full != "" {
+		return mr.References.Full
+	}
+	return "!" + strconv.Itoa(mr.IID)
+}
+
+func init() {
+	groupCmd.AddCommand(groupShowCmd)
+	RootCmd.AddCommand(groupCmd)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_projectNamespace(t *testing.T) {
+	assert.Equal(t, "zaquestion", projectNamespace("zaquestion/lab"))
+	assert.Equal(t, "group/sub-group", projectNamespace("group/sub-group/project"))
+	assert.Equal(t, "", projectNamespace("project"))
+}
+
+func Test_issueFullReference(t *testing.T) {
+	issue := &gitlab.Issue{IID: 12}
+	assert.Equal(t, "#12", issueFullReference(issue))
+
+	issue.References = &gitlab.IssueReferences{Full: "group/project#12"}
+	assert.Equal(t, "group/project#12", issueFullReference(issue))
+}
+
+func Test_mrFullReference(t *testing.T) {
+	mr := &gitlab.BasicMergeRequest{IID: 3}
+	assert.Equal(t, "!3", mrFullReference(mr))
+
+	mr.References = &gitlab.IssueReferences{Full: "group/project!3"}
+	assert.Equal(t, "group/project!3", mrFullReference(mr))
+}
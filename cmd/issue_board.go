@@ -0,0 +1,607 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pkg/errors"
+	"github.com/rivo/tview"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// number of closed issues shown in the Closed list of a board
+const boardClosedIssues = 20
+
+// issueBoard is a project or group board, limited to its label lists
+type issueBoard struct {
+	name      string
+	lists     []*gitlab.BoardList
+	labels    []string
+	milestone string
+}
+
+// boardColumn is a list of a board, as shown on screen
+type boardColumn struct {
+	title  string
+	label  string
+	closed bool
+	issues []*gitlab.Issue
+}
+
+var issueBoardCmd = &cobra.Command{
+	Use:   "board [remote] [name]",
+	Short: "Show an issue board in the terminal",
+	Long: heredoc.Doc(`
+		Show the lists of an issue board as columns, along with the open
+		issues without any list label and the recently closed issues. The
+		first board is shown when no name is given; group boards are shown
+		with --group.
+
+		Moving an issue to another list changes its labels the way GitLab
+		does, and moving it to or from the Closed list closes or reopens it.
+
+		'h', 'l' or the arrow keys select a list, and 'j', 'k' an issue
+		'H', 'L' move the issue to the previous or next list
+		'J', 'K' move the issue down or up in the list
+		<enter> shows the details of the issue
+		'a' sets the assignees of the issue
+		'c' closes the issue
+		'r' refreshes the board
+		'q' or <esc> quits`),
+	Example: heredoc.Doc(`
+		lab issue board
+		lab issue board upstream Development
+		lab issue board --group my-group`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		group, err := cmd.Flags().GetString("group")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		boards, err := issueBoards(rn, group)
+		if err != nil {
+			log.Fatal(err)
+		}
+		board, err := findIssueBoard(boards, name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		load := func() ([]*boardColumn, error) {
+			open, closed, err := boardIssues(rn, group, board)
+			if err != nil {
+				return nil, err
+			}
+			return boardColumns(board.lists, open, closed), nil
+		}
+		columns, err := load()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		v := newIssueBoardView(board.name, columns, load)
+		defer recoverPanic(v.app)
+		if err := v.app.Run(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// issueBoards gets the boards of the group, if any, or of the project
+func issueBoards(project, group string) ([]*issueBoard, error) {
+	var boards []*issueBoard
+	if group != "" {
+		list, err := lab.GroupIssueBoards(group)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range list {
+			var labels []string
+			for _, l := range b.Labels {
+				labels = append(labels, l.Name)
+			}
+			boards = append(boards, newIssueBoard(b.Name, b.Lists, labels, b.Milestone))
+		}
+		return boards, nil
+	}
+
+	list, err := lab.IssueBoards(project)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range list {
+		var labels []string
+		for _, l := range b.Labels {
+			labels = append(labels, l.Name)
+		}
+		boards = append(boards, newIssueBoard(b.Name, b.Lists, labels, b.Milestone))
+	}
+	return boards, nil
+}
+
+func newIssueBoard(name string, lists []*gitlab.BoardList, labels []string, milestone *gitlab.Milestone) *issueBoard {
+	board := &issueBoard{name: name, lists: lists, labels: labels}
+	if milestone != nil {
+		board.milestone = milestone.Title
+	}
+	return board
+}
+
+// findIssueBoard returns the board with the given name, or the first one
+// when no name is given
+func findIssueBoard(boards []*issueBoard, name string) (*issueBoard, error) {
+	var names []string
+	for _, b := range boards {
+		if name == "" || strings.EqualFold(b.name, name) {
+			return b, nil
+		}
+		names = append(names, b.name)
+	}
+	return nil, boardNotFound(name, names)
+}
+
+func boardNotFound(name string, names []string) error {
+	if name == "" {
+		return errors.New("no issue board found")
+	}
+	return errors.Errorf("issue board %q not found, available boards: %s", name, strings.Join(names, ", "))
+}
+
+// boardIssues gets the open issues and the recently closed issues in the
+// scope of the board, in the board order
+func boardIssues(project, group string, board *issueBoard) ([]*gitlab.Issue, []*gitlab.Issue, error) {
+	var (
+		labels    *gitlab.LabelOptions
+		milestone *string
+		orderBy   = "relative_position"
+		updated   = "updated_at"
+		asc       = "asc"
+		opened    = "opened"
+		closed    = "closed"
+	)
+	if len(board.labels) > 0 {
+		l := gitlab.LabelOptions(board.labels)
+		labels = &l
+	}
+	if board.milestone != "" {
+		milestone = &board.milestone
+	}
+
+	if group != "" {
+		open, err := lab.GroupIssueList(group, gitlab.ListGroupIssuesOptions{
+			State: &opened, Labels: labels, Milestone: milestone, OrderBy: &orderBy, Sort: &asc,
+		}, -1)
+		if err != nil {
+			return nil, nil, err
+		}
+		done, err := lab.GroupIssueList(group, gitlab.ListGroupIssuesOptions{
+			State: &closed, Labels: labels, Milestone: milestone, OrderBy: &updated,
+		}, boardClosedIssues)
+		return open, done, err
+	}
+
+	open, err := lab.IssueList(project, gitlab.ListProjectIssuesOptions{
+		State: &opened, Labels: labels, Milestone: milestone, OrderBy: &orderBy, Sort: &asc,
+	}, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	done, err := lab.IssueList(project, gitlab.ListProjectIssuesOptions{
+		State: &closed, Labels: labels, Milestone: milestone, OrderBy: &updated,
+	}, boardClosedIssues)
+	return open, done, err
+}
+
+// boardColumns distributes the issues in the lists of a board. Like on
+// GitLab, an open issue is shown in every list matching one of its labels,
+// and in the Open list when it matches none. Lists not based on a label
+// aren't supported.
+func boardColumns(lists []*gitlab.BoardList, open, closed []*gitlab.Issue) []*boardColumn {
+	labelLists := make([]*gitlab.BoardList, 0, len(lists))
+	for _, l := range lists {
+		if l.Label != nil {
+			labelLists = append(labelLists, l)
+		}
+	}
+	sort.SliceStable(labelLists, func(i, j int) bool {
+		return labelLists[i].Position < labelLists[j].Position
+	})
+
+	backlog := &boardColumn{title: "Open"}
+	columns := []*boardColumn{backlog}
+	for _, l := range labelLists {
+		columns = append(columns, &boardColumn{title: l.Label.Name, label: l.Label.Name})
+	}
+
+	for _, issue := range open {
+		listed := false
+		for _, c := range columns[1:] {
+			for _, label := range issue.Labels {
+				if label == c.label {
+					c.issues = append(c.issues, issue)
+					listed = true
+				}
+			}
+		}
+		if !listed {
+			backlog.issues = append(backlog.issues, issue)
+		}
+	}
+
+	return append(columns, &boardColumn{title: "Closed", closed: true, issues: closed})
+}
+
+// boardMoveOptions returns the changes moving an issue between two lists
+func boardMoveOptions(from, to *boardColumn) *gitlab.UpdateIssueOptions {
+	opts := &gitlab.UpdateIssueOptions{}
+	if from.label != "" {
+		opts.RemoveLabels = &gitlab.LabelOptions{from.label}
+	}
+	if to.label != "" {
+		opts.AddLabels = &gitlab.LabelOptions{to.label}
+	}
+	switch {
+	case to.closed:
+		opts.StateEvent = gitlab.String("close")
+	case from.closed:
+		opts.StateEvent = gitlab.String("reopen")
+	}
+	return opts
+}
+
+// boardIssueText returns the text of an issue in the list of a column
+func boardIssueText(issue *gitlab.Issue, column *boardColumn) (string, string) {
+	var details []string
+	for _, a := range issue.Assignees {
+		details = append(details, "@"+a.Username)
+	}
+	for _, l := range issue.Labels {
+		if l != column.label {
+			details = append(details, "~"+l)
+		}
+	}
+	return fmt.Sprintf("#%d %s", issue.IID, issue.Title), strings.Join(details, " ")
+}
+
+// boardIssueDetails returns the description of an issue shown on <enter>
+func boardIssueDetails(issue *gitlab.Issue) string {
+	assignees := make([]string, len(issue.Assignees))
+	for i, a := range issue.Assignees {
+		assignees[i] = a.Username
+	}
+	milestone := "None"
+	if issue.Milestone != nil {
+		milestone = issue.Milestone.Title
+	}
+	author := ""
+	if issue.Author != nil {
+		author = issue.Author.Username
+	}
+
+	return fmt.Sprintf(heredoc.Doc(`
+		#%d %s
+		===================================
+		%s
+		-----------------------------------
+		Status: %s
+		Assignees: %s
+		Author: %s
+		Milestone: %s
+		Labels: %s
+		WebURL: %s`),
+		issue.IID, issue.Title, issue.Description, issue.State,
+		strings.Join(assignees, ", "), author, milestone,
+		strings.Join(issue.Labels, ", "), issue.WebURL)
+}
+
+const boardHelp = "h/l: list  j/k: issue  H/L: move  J/K: reorder  enter: details  a: assign  c: close  r: refresh  q: quit"
+
+// issueBoardView is the terminal UI of a board
+type issueBoardView struct {
+	app     *tview.Application
+	pages   *tview.Pages
+	flex    *tview.Flex
+	status  *tview.TextView
+	lists   []*tview.List
+	columns []*boardColumn
+	focus   int
+	load    func() ([]*boardColumn, error)
+}
+
+func newIssueBoardView(name string, columns []*boardColumn, load func() ([]*boardColumn, error)) *issueBoardView {
+	v := &issueBoardView{
+		app:     tview.NewApplication(),
+		pages:   tview.NewPages(),
+		flex:    tview.NewFlex(),
+		status:  tview.NewTextView(),
+		columns: columns,
+		load:    load,
+	}
+	v.status.SetText(boardHelp)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.flex, 0, 1, true).
+		AddItem(v.status, 1, 0, false)
+	layout.SetBorder(true).SetTitle(" " + name + " ")
+	v.pages.AddPage("board", layout, true, true)
+
+	v.render()
+	v.app.SetRoot(v.pages, true).SetInputCapture(v.inputCapture)
+	return v
+}
+
+// render rebuilds the lists from the columns, keeping the selection
+func (v *issueBoardView) render() {
+	selected := make([]int, len(v.lists))
+	for i, l := range v.lists {
+		selected[i] = l.GetCurrentItem()
+	}
+
+	v.flex.Clear()
+	v.lists = make([]*tview.List, len(v.columns))
+	for i, c := range v.columns {
+		l := tview.NewList().ShowSecondaryText(true).SetSelectedFocusOnly(true)
+		l.SetBorder(true).SetTitle(fmt.Sprintf(" %s (%d) ", c.title, len(c.issues)))
+		for _, issue := range c.issues {
+			main, secondary := boardIssueText(issue, c)
+			l.AddItem(main, secondary, 0, nil)
+		}
+		if i < len(selected) && selected[i] < len(c.issues) {
+			l.SetCurrentItem(selected[i])
+		}
+		l.SetSelectedFunc(func(int, string, string, rune) {
+			v.showDetails()
+		})
+		v.lists[i] = l
+		v.flex.AddItem(l, 0, 1, i == v.focus)
+	}
+	if v.focus >= len(v.lists) {
+		v.focus = len(v.lists) - 1
+	}
+	v.app.SetFocus(v.lists[v.focus])
+}
+
+// current returns the selected column and issue, if any
+func (v *issueBoardView) current() (*boardColumn, *gitlab.Issue) {
+	c := v.columns[v.focus]
+	i := v.lists[v.focus].GetCurrentItem()
+	if i < 0 || i >= len(c.issues) {
+		return c, nil
+	}
+	return c, c.issues[i]
+}
+
+// reload fetches the board again, selecting the given issue when set
+func (v *issueBoardView) reload(focus int, issue *gitlab.Issue) {
+	columns, err := v.load()
+	if err != nil {
+		v.setError(err)
+		return
+	}
+	v.columns = columns
+	v.focus = focus
+	v.render()
+	if issue == nil {
+		return
+	}
+	for i, is := range v.columns[focus].issues {
+		if is.ID == issue.ID {
+			v.lists[focus].SetCurrentItem(i)
+		}
+	}
+}
+
+func (v *issueBoardView) setError(err error) {
+	v.status.SetText("[red]" + tview.Escape(err.Error()))
+	v.status.SetDynamicColors(true)
+}
+
+func (v *issueBoardView) inputCapture(event *tcell.EventKey) *tcell.EventKey {
+	front, _ := v.pages.GetFrontPage()
+	if front != "board" {
+		if event.Key() == tcell.KeyEscape || (front == "details" && event.Rune() == 'q') {
+			v.pages.RemovePage(front)
+			v.app.SetFocus(v.lists[v.focus])
+			return nil
+		}
+		return event
+	}
+
+	v.status.SetText(boardHelp)
+	switch event.Key() {
+	case tcell.KeyEscape:
+		v.app.Stop()
+		return nil
+	case tcell.KeyLeft:
+		v.setFocus(v.focus - 1)
+		return nil
+	case tcell.KeyRight:
+		v.setFocus(v.focus + 1)
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'q':
+		v.app.Stop()
+	case 'h':
+		v.setFocus(v.focus - 1)
+	case 'l':
+		v.setFocus(v.focus + 1)
+	case 'j':
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	case 'k':
+		return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+	case 'H':
+		v.move(v.focus - 1)
+	case 'L':
+		v.move(v.focus + 1)
+	case 'J':
+		v.reorder(1)
+	case 'K':
+		v.reorder(-1)
+	case 'a':
+		v.assign()
+	case 'c':
+		v.closeIssue()
+	case 'r':
+		_, issue := v.current()
+		v.reload(v.focus, issue)
+	default:
+		return event
+	}
+	return nil
+}
+
+func (v *issueBoardView) setFocus(focus int) {
+	if focus < 0 || focus >= len(v.lists) {
+		return
+	}
+	v.focus = focus
+	v.app.SetFocus(v.lists[focus])
+}
+
+// move moves the selected issue to the list at index to
+func (v *issueBoardView) move(to int) {
+	from, issue := v.current()
+	if issue == nil || to < 0 || to >= len(v.columns) {
+		return
+	}
+
+	_, err := lab.IssueUpdate(issue.ProjectID, issue.IID, boardMoveOptions(from, v.columns[to]))
+	if err != nil {
+		v.setError(err)
+		return
+	}
+	v.reload(to, issue)
+}
+
+// reorder moves the selected issue down or up in its list
+func (v *issueBoardView) reorder(delta int) {
+	c, issue := v.current()
+	i := v.lists[v.focus].GetCurrentItem() + delta
+	if issue == nil || c.closed || i < 0 || i >= len(c.issues) {
+		return
+	}
+
+	var err error
+	if delta < 0 {
+		err = lab.IssueReorder(issue.ProjectID, issue.IID, &c.issues[i].ID, nil)
+	} else {
+		err = lab.IssueReorder(issue.ProjectID, issue.IID, nil, &c.issues[i].ID)
+	}
+	if err != nil {
+		v.setError(err)
+		return
+	}
+	v.reload(v.focus, issue)
+}
+
+func (v *issueBoardView) showDetails() {
+	_, issue := v.current()
+	if issue == nil {
+		return
+	}
+
+	details := tview.NewTextView().SetText(boardIssueDetails(issue)).SetWordWrap(true)
+	details.SetBorder(true).SetTitle(" q: back ")
+	v.pages.AddPage("details", details, true, true)
+	v.app.SetFocus(details)
+}
+
+func (v *issueBoardView) assign() {
+	_, issue := v.current()
+	if issue == nil {
+		return
+	}
+
+	current := make([]string, len(issue.Assignees))
+	for i, a := range issue.Assignees {
+		current[i] = a.Username
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("Assignees", strings.Join(current, ","), 40, nil, nil)
+	form.AddButton("Save", func() {
+		usernames := strings.FieldsFunc(form.GetFormItem(0).(*tview.InputField).GetText(), func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+		v.pages.RemovePage("assign")
+
+		// an empty list has to be sent as [0] to remove all assignees
+		ids := []int{0}
+		if len(usernames) > 0 {
+			ids = ids[:0]
+		}
+		for _, u := range usernames {
+			id := getUserID(strings.TrimPrefix(u, "@"))
+			if id == nil {
+				v.setError(errors.Errorf("%s is not a valid username", u))
+				return
+			}
+			ids = append(ids, *id)
+		}
+
+		_, err := lab.IssueUpdate(issue.ProjectID, issue.IID, &gitlab.UpdateIssueOptions{AssigneeIDs: &ids})
+		if err != nil {
+			v.setError(err)
+			return
+		}
+		v.reload(v.focus, issue)
+	})
+	form.AddButton("Cancel", func() {
+		v.pages.RemovePage("assign")
+		v.app.SetFocus(v.lists[v.focus])
+	})
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Assign #%d ", issue.IID))
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 7, 0, true).
+			AddItem(nil, 0, 1, false), 60, 0, true).
+		AddItem(nil, 0, 1, false)
+	v.pages.AddPage("assign", modal, true, true)
+	v.app.SetFocus(form)
+}
+
+func (v *issueBoardView) closeIssue() {
+	c, issue := v.current()
+	if issue == nil || c.closed {
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Close #%d %s?", issue.IID, issue.Title)).
+		AddButtons([]string{"No", "Yes"}).
+		SetDoneFunc(func(_ int, label string) {
+			v.pages.RemovePage("close")
+			v.app.SetFocus(v.lists[v.focus])
+			if label == "Yes" {
+				v.move(len(v.columns) - 1)
+			}
+		})
+	v.pages.AddPage("close", modal, false, true)
+	v.app.SetFocus(modal)
+}
+
+func init() {
+	issueBoardCmd.Flags().String("group", "", "show a board of the given group instead of the project")
+	issueCmd.AddCommand(issueBoardCmd)
+	carapace.Gen(issueBoardCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
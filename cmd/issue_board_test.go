@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_boardColumns(t *testing.T) {
+	lists := []*gitlab.BoardList{
+		{ID: 1, Label: &gitlab.Label{Name: "Doing"}, Position: 1},
+		{ID: 2, Label: &gitlab.Label{Name: "To Do"}, Position: 0},
+		{ID: 3, Assignee: &struct {
+			ID       int    `json:"id"`
+			Name     string `json:"name"`
+			Username string `json:"username"`
+		}{Username: "jdoe"}, Position: 2},
+	}
+	open := []*gitlab.Issue{
+		{IID: 1, Labels: []string{"bug"}},
+		{IID: 2, Labels: []string{"To Do"}},
+		{IID: 3, Labels: []string{"Doing", "To Do"}},
+	}
+	closed := []*gitlab.Issue{{IID: 4, Labels: []string{"Doing"}}}
+
+	columns := boardColumns(lists, open, closed)
+	require.Len(t, columns, 4)
+
+	titles := make([]string, len(columns))
+	for i, c := range columns {
+		titles[i] = c.title
+	}
+	assert.Equal(t, []string{"Open", "To Do", "Doing", "Closed"}, titles)
+	assert.Equal(t, []*gitlab.Issue{open[0]}, columns[0].issues)
+	assert.Equal(t, []*gitlab.Issue{open[1], open[2]}, columns[1].issues)
+	assert.Equal(t, []*gitlab.Issue{open[2]}, columns[2].issues)
+	assert.Equal(t, closed, columns[3].issues)
+	assert.True(t, columns[3].closed)
+}
+
+func Test_boardMoveOptions(t *testing.T) {
+	backlog := &boardColumn{title: "Open"}
+	todo := &boardColumn{title: "To Do", label: "To Do"}
+	doing := &boardColumn{title: "Doing", label: "Doing"}
+	closed := &boardColumn{title: "Closed", closed: true}
+
+	opts := boardMoveOptions(todo, doing)
+	assert.Equal(t, &gitlab.LabelOptions{"To Do"}, opts.RemoveLabels)
+	assert.Equal(t, &gitlab.LabelOptions{"Doing"}, opts.AddLabels)
+	assert.Nil(t, opts.StateEvent)
+
+	opts = boardMoveOptions(backlog, todo)
+	assert.Nil(t, opts.RemoveLabels)
+	assert.Equal(t, &gitlab.LabelOptions{"To Do"}, opts.AddLabels)
+
+	opts = boardMoveOptions(doing, closed)
+	assert.Equal(t, &gitlab.LabelOptions{"Doing"}, opts.RemoveLabels)
+	assert.Nil(t, opts.AddLabels)
+	assert.Equal(t, "close", *opts.StateEvent)
+
+	opts = boardMoveOptions(closed, backlog)
+	assert.Nil(t, opts.RemoveLabels)
+	assert.Nil(t, opts.AddLabels)
+	assert.Equal(t, "reopen", *opts.StateEvent)
+}
+
+func Test_boardIssueText(t *testing.T) {
+	issue := &gitlab.Issue{
+		IID:       12,
+		Title:     "Fix the board",
+		Labels:    []string{"Doing", "bug"},
+		Assignees: []*gitlab.IssueAssignee{{Username: "jdoe"}},
+	}
+	main, secondary := boardIssueText(issue, &boardColumn{label: "Doing"})
+	assert.Equal(t, "#12 Fix the board", main)
+	assert.Equal(t, "@jdoe ~bug", secondary)
+}
+
+func Test_findIssueBoard(t *testing.T) {
+	boards := []*issueBoard{{name: "Development"}, {name: "Release"}}
+
+	board, err := findIssueBoard(boards, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Development", board.name)
+
+	board, err = findIssueBoard(boards, "release")
+	require.NoError(t, err)
+	assert.Equal(t, "Release", board.name)
+
+	_, err = findIssueBoard(boards, "Triage")
+	assert.EqualError(t, err, `issue board "Triage" not found, available boards: Development, Release`)
+
+	_, err = findIssueBoard(nil, "")
+	assert.EqualError(t, err, "no issue board found")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var issueBulkCmd = &cobra.Command{
+	Use:   "bulk [remote] [search]",
+	Short: "Edit all the issues matching the given filters",
+	Long: heredoc.Doc(`
+		Edit all the issues selected by the same filters as "lab issue list",
+		and optionally by age with --older-than. Labels and assignees can be
+		added and removed, the milestone set, a note added, and the issues
+		closed.
+
+		The selected issues and the changes are shown before asking for
+		confirmation, or only shown with --dry-run. The changes are made
+		in parallel and the issues that couldn't be changed are reported
+		at the end.`),
+	Example: heredoc.Doc(`
+		lab issue bulk --older-than 180 --close -m "Closing stale issue"
+		lab issue bulk upstream -l bug --add-label needs-triage --dry-run
+		lab issue bulk --milestone "1.0" --set-milestone "1.1" -y
+		lab issue bulk "crash" --assign johndoe --remove-label new`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, _, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		issueLabels, _ = cmd.Flags().GetStringSlice("label")
+		issueMilestone, _ = cmd.Flags().GetString("milestone")
+		issueAuthor, _ = cmd.Flags().GetString("author")
+		issueAssignee, _ = cmd.Flags().GetString("assignee")
+		issueState, _ = cmd.Flags().GetString("state")
+		issueNumRet = "-1"
+		days, err := bulkAge(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		edit, err := bulkEditFromFlags(cmd, rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		issues, err := issueList(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var items []bulkItem
+		for _, issue := range issues {
+			item := bulkItem{IID: issue.IID, Title: issue.Title, UpdatedAt: issue.UpdatedAt}
+			for _, a := range issue.Assignees {
+				item.Assignees = append(item.Assignees, a.ID)
+			}
+			items = append(items, item)
+		}
+		items = bulkFilterOlderThan(items, days, time.Now())
+
+		runBulk(cmd, items, edit, "issue", "#", func(item bulkItem) error {
+			opts := &gitlab.UpdateIssueOptions{
+				MilestoneID: edit.milestoneID,
+			}
+			if len(edit.addLabels) > 0 {
+				opts.AddLabels = &edit.addLabels
+			}
+			if len(edit.removeLabels) > 0 {
+				opts.RemoveLabels = &edit.removeLabels
+			}
+			if edit.changesAssignees() {
+				assignees := edit.assignees(item.Assignees)
+				opts.AssigneeIDs = &assignees
+			}
+			if edit.close {
+				opts.StateEvent = gitlab.String("close")
+			}
+
+			if edit.note != "" {
+				_, err := lab.IssueCreateNote(rn, item.IID, &gitlab.CreateIssueNoteOptions{
+					Body: &edit.note,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if *opts == (gitlab.UpdateIssueOptions{}) {
+				return nil
+			}
+			_, err := lab.IssueUpdate(rn, item.IID, opts)
+			return err
+		})
+	},
+}
+
+func init() {
+	bulkAddFlags(issueBulkCmd)
+	issueBulkCmd.Flags().SortFlags = false
+	issueCmd.AddCommand(issueBulkCmd)
+
+	carapace.Gen(issueBulkCmd).FlagCompletion(carapace.ActionMap{
+		"label":         bulkLabelCompletion(),
+		"add-label":     bulkLabelCompletion(),
+		"remove-label":  bulkLabelCompletion(),
+		"milestone":     bulkMilestoneCompletion(),
+		"set-milestone": bulkMilestoneCompletion(),
+		"state":         carapace.ActionValues("all", "opened", "closed"),
+	})
+	carapace.Gen(issueBulkCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
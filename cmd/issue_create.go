@@ -10,6 +10,7 @@ import (
 	"text/template"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
 	"github.com/rsteube/carapace"
 	"github.com/spf13/cobra"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -22,14 +23,35 @@ var issueCreateCmd = &cobra.Command{
 	Use:     "create [remote]",
 	Aliases: []string{"new"},
 	Short:   "Open an issue on GitLab",
-	Args:    cobra.MaximumNArgs(1),
+	Long: heredoc.Doc(`
+		Open an issue, with the title and description given with -m, read
+		from a file given with -F, or written in the editor from a template.
+
+		The file and the templates can start with a frontmatter setting the
+		weight, due_date, confidential, type, epic, iteration, time_estimate
+		and linked_mr fields, the flags overriding them:
+
+		  ---
+		  weight: 3
+		  due_date: 2024-07-01
+		  type: incident
+		  linked_mr: 15, 16
+		  ---
+		  Title of the issue
+
+		  Description of the issue`),
+	Args: cobra.MaximumNArgs(1),
 	Example: heredoc.Doc(`
 		lab issue create
 		lab issue create origin -a johndoe -a janedoe
 		lab issue create origin -l bug
 		lab issue create upstream -m "new issue related to the --help arg"
 		lab issue create upstream --milestone "July"
-		lab issue create upstream --template "API-BUG"`),
+		lab issue create upstream --template "API-BUG"
+		lab issue create --weight 3 --due-date 2024-07-01 --type incident
+		lab issue create --confidential --epic 42 --time-estimate 2h
+		lab issue create --iteration "Sprint 12" --linked-mr 15
+		lab issue create -F issue.md`),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		msgs, err := cmd.Flags().GetStringArray("message")
@@ -52,6 +74,10 @@ var issueCreateCmd = &cobra.Command{
 		if err != nil {
 			log.Fatal(err)
 		}
+		filename, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatal(err)
+		}
 		remote := defaultRemote
 		if len(args) > 0 {
 			ok, err := git.IsRemote(args[0])
@@ -81,7 +107,7 @@ var issueCreateCmd = &cobra.Command{
 			milestoneID = &milestone.ID
 		}
 
-		title, body, err := issueMsg(templateName, msgs)
+		title, body, frontmatter, err := issueMsg(templateName, msgs, filename)
 		if err != nil {
 			_, f, l, _ := runtime.Caller(0)
 			log.Fatal(f+":"+strconv.Itoa(l)+" ", err)
@@ -90,6 +116,16 @@ var issueCreateCmd = &cobra.Command{
 			log.Fatal("aborting issue due to empty issue msg")
 		}
 
+		fields, err := issueFieldsFromFrontmatter(frontmatter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		flagFields, err := issueFieldsFromFlags(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fields.merge(flagFields)
+
 		linebreak, _ := cmd.Flags().GetBool("force-linebreak")
 		if linebreak {
 			body = textToMarkdown(body)
@@ -101,29 +137,264 @@ var issueCreateCmd = &cobra.Command{
 		}
 
 		issueURL, err := lab.IssueCreate(rn, &gitlab.CreateIssueOptions{
-			Title:       &title,
-			Description: &body,
-			Labels:      &labels,
-			AssigneeIDs: &assigneeIDs,
-			MilestoneID: milestoneID,
+			Title:        &title,
+			Description:  &body,
+			Labels:       &labels,
+			AssigneeIDs:  &assigneeIDs,
+			MilestoneID:  milestoneID,
+			Weight:       fields.weight,
+			DueDate:      fields.dueDate,
+			Confidential: fields.confidential,
+			IssueType:    fields.issueType,
+			EpicID:       fields.epicID,
 		})
 		if err != nil {
 			log.Fatal(err)
 		}
+
+		// the issue exists from now on, print it whatever happens next
 		fmt.Println(issueURL)
+
+		id, err := issueIDFromURL(issueURL)
+		if err == nil {
+			err = fields.applyAfterUpdate(rn, id)
+		}
+		if err != nil {
+			log.Fatalf("issue created, but not all its fields could be set: %s", err)
+		}
 	},
 }
 
-func issueMsg(templateName string, msgs []string) (string, string, error) {
-	if len(msgs) > 0 {
-		return msgs[0], strings.Join(msgs[1:], "\n\n"), nil
+// issueFields holds the issue fields set by flags or by the frontmatter of
+// the issue text, nil when not set
+type issueFields struct {
+	weight       *int
+	dueDate      *gitlab.ISOTime
+	confidential *bool
+	issueType    *string
+	epicID       *int
+	iteration    string
+	timeEstimate string
+	linkedMRs    []int
+}
+
+// issueTypes are the types of issues that can be created
+var issueTypes = []string{"issue", "incident", "task"}
+
+// issueFieldsFromFrontmatter parses the fields set in the frontmatter
+func issueFieldsFromFrontmatter(frontmatter map[string]string) (issueFields, error) {
+	var (
+		fields issueFields
+		err    error
+	)
+	for key, value := range frontmatter {
+		switch key {
+		case "weight":
+			fields.weight, err = parseIssueInt(key, value)
+		case "due_date":
+			fields.dueDate, err = parseIssueDueDate(value)
+		case "confidential":
+			var confidential bool
+			confidential, err = strconv.ParseBool(value)
+			fields.confidential = &confidential
+		case "type":
+			fields.issueType, err = parseIssueType(value)
+		case "epic":
+			fields.epicID, err = parseIssueInt(key, value)
+		case "iteration":
+			fields.iteration = value
+		case "time_estimate":
+			fields.timeEstimate = value
+		case "linked_mr", "linked_mrs":
+			fields.linkedMRs, err = parseIssueIntList(key, value)
+		default:
+			err = errors.Errorf("unknown frontmatter field %q", key)
+		}
+		if err != nil {
+			return fields, err
+		}
+	}
+	return fields, nil
+}
+
+// issueFieldsFromFlags parses the fields set with the flags added by
+// issueAddFieldFlags
+func issueFieldsFromFlags(cmd *cobra.Command) (issueFields, error) {
+	var (
+		fields issueFields
+		err    error
+	)
+	flags := cmd.Flags()
+
+	if value, _ := flags.GetString("weight"); value != "" {
+		fields.weight, err = parseIssueInt("weight", value)
+		if err != nil {
+			return fields, err
+		}
+	}
+	if value, _ := flags.GetString("due-date"); value != "" {
+		fields.dueDate, err = parseIssueDueDate(value)
+		if err != nil {
+			return fields, err
+		}
+	}
+	if flags.Changed("confidential") {
+		confidential, _ := flags.GetBool("confidential")
+		fields.confidential = &confidential
+	}
+	if value, _ := flags.GetString("type"); value != "" {
+		fields.issueType, err = parseIssueType(value)
+		if err != nil {
+			return fields, err
+		}
+	}
+	if value, _ := flags.GetString("epic"); value != "" {
+		fields.epicID, err = parseIssueInt("epic", value)
+		if err != nil {
+			return fields, err
+		}
+	}
+	fields.iteration, _ = flags.GetString("iteration")
+	fields.timeEstimate, _ = flags.GetString("time-estimate")
+	linkedMRs, _ := flags.GetStringSlice("linked-mr")
+	fields.linkedMRs, err = parseIssueIntList("linked-mr", strings.Join(linkedMRs, ","))
+	return fields, err
+}
+
+// merge overrides the fields with the ones set in other
+func (f *issueFields) merge(other issueFields) {
+	if other.weight != nil {
+		f.weight = other.weight
+	}
+	if other.dueDate != nil {
+		f.dueDate = other.dueDate
+	}
+	if other.confidential != nil {
+		f.confidential = other.confidential
+	}
+	if other.issueType != nil {
+		f.issueType = other.issueType
+	}
+	if other.epicID != nil {
+		f.epicID = other.epicID
+	}
+	if other.iteration != "" {
+		f.iteration = other.iteration
+	}
+	if other.timeEstimate != "" {
+		f.timeEstimate = other.timeEstimate
+	}
+	if len(other.linkedMRs) > 0 {
+		f.linkedMRs = other.linkedMRs
+	}
+}
+
+// empty returns whether no field is set
+func (f issueFields) empty() bool {
+	return f.weight == nil && f.dueDate == nil && f.confidential == nil &&
+		f.issueType == nil && f.epicID == nil && f.iteration == "" &&
+		f.timeEstimate == "" && len(f.linkedMRs) == 0
+}
+
+// applyAfterUpdate sets the fields that can't be set when creating or
+// updating the issue: the time estimate, the iteration, with a quick action
+// as the API has no parameter for it, and the linked MRs, by mentioning the
+// issue in them
+func (f issueFields) applyAfterUpdate(rn string, id int) error {
+	if f.timeEstimate != "" {
+		_, err := lab.IssueSetTimeEstimate(rn, id, f.timeEstimate)
+		if err != nil {
+			return err
+		}
+	}
+	if f.iteration != "" {
+		iteration := fmt.Sprintf("%q", f.iteration)
+		if _, err := strconv.Atoi(f.iteration); err == nil {
+			iteration = f.iteration
+		}
+		_, err := lab.IssueCreateNote(rn, id, &gitlab.CreateIssueNoteOptions{
+			Body: gitlab.String("/iteration *iteration:" + iteration),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, mr := range f.linkedMRs {
+		_, err := lab.MRCreateNote(rn, mr, &gitlab.CreateMergeRequestNoteOptions{
+			Body: gitlab.String(fmt.Sprintf("Related to #%d", id)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueAddFieldFlags adds the flags parsed by issueFieldsFromFlags
+func issueAddFieldFlags(cmd *cobra.Command) {
+	cmd.Flags().String("weight", "", "set the weight")
+	cmd.Flags().String("due-date", "", "set the due date, as YYYY-MM-DD")
+	cmd.Flags().Bool("confidential", false, "make the issue confidential")
+	cmd.Flags().String("type", "", "set the type: issue, incident or task")
+	cmd.Flags().String("epic", "", "add the issue to the epic with the given ID")
+	cmd.Flags().String("iteration", "", "set the iteration by ID or title")
+	cmd.Flags().String("time-estimate", "", "set the time estimate, as a duration such as 3h30m")
+	cmd.Flags().StringSlice("linked-mr", []string{}, "link the issue to the given merge request(s)")
+}
+
+func parseIssueInt(name, value string) (*int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return nil, errors.Errorf("invalid %s %q", name, value)
+	}
+	return &n, nil
+}
+
+func parseIssueIntList(name, value string) ([]int, error) {
+	var list []int
+	for _, item := range splitIssueRecordList(strings.Trim(value, "[]")) {
+		n, err := parseIssueInt(name, strings.TrimPrefix(item, "!"))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *n)
+	}
+	return list, nil
+}
+
+func parseIssueDueDate(value string) (*gitlab.ISOTime, error) {
+	dueDate, err := gitlab.ParseISOTime(value)
+	if err != nil {
+		return nil, errors.Errorf("invalid due date %q, use YYYY-MM-DD", value)
+	}
+	return &dueDate, nil
+}
+
+func parseIssueType(value string) (*string, error) {
+	value = strings.ToLower(value)
+	if !contains(issueTypes, value) {
+		return nil, errors.Errorf("invalid issue type %q, use %s", value, strings.Join(issueTypes, ", "))
+	}
+	return &value, nil
+}
+
+// issueIDFromURL returns the id of an issue from its URL
+func issueIDFromURL(issueURL string) (int, error) {
+	return strconv.Atoi(issueURL[strings.LastIndex(issueURL, "/")+1:])
+}
+
+// issueMsg returns the title, description and frontmatter of a new issue,
+// given with -m, read from a file or written in the editor
+func issueMsg(templateName string, msgs []string, filename string) (string, string, map[string]string, error) {
+	if len(msgs) > 0 || filename != "" {
+		return editIssueDescription("", "", msgs, filename)
 	}
 
 	text, err := issueText(templateName)
 	if err != nil {
-		return "", "", err
+		return "", "", nil, err
 	}
-	return git.Edit("ISSUE", text)
+	return editDescriptionText("ISSUE", text, true)
 }
 
 func issueText(templateName string) (string, error) {
@@ -136,10 +407,22 @@ func issueText(templateName string) (string, error) {
 	templateFile += ".md"
 	issueTmpl := lab.LoadGitLabTmpl(templateFile)
 
+	// the frontmatter of the template must stay at the top of the text
+	frontmatter := ""
+	fields, rest, err := parseFrontmatter(issueTmpl)
+	if err != nil {
+		return "", err
+	}
+	if fields != nil {
+		frontmatter = strings.TrimSuffix(issueTmpl, rest)
+		issueTmpl = strings.TrimLeft(rest, "\n")
+	}
+
 	initMsg := "\n"
 	if issueTmpl != "" {
 		initMsg = "\n\n" + issueTmpl
 	}
+	initMsg = frontmatter + initMsg
 
 	commentChar := git.CommentChar()
 
@@ -171,11 +454,15 @@ func init() {
 	issueCreateCmd.Flags().StringSliceP("assignees", "a", []string{}, "set assignees by username")
 	issueCreateCmd.Flags().String("milestone", "", "set milestone by title")
 	issueCreateCmd.Flags().StringP("template", "t", "default", "use the given issue template")
+	issueCreateCmd.Flags().StringP("file", "F", "", "use the given file as the title, description and frontmatter of the issue")
 	issueCreateCmd.Flags().Bool("force-linebreak", false, "append 2 spaces to the end of each line to force markdown linebreaks")
+	issueAddFieldFlags(issueCreateCmd)
 
 	issueCmd.AddCommand(issueCreateCmd)
 
 	carapace.Gen(issueCreateCmd).FlagCompletion(carapace.ActionMap{
+		"file": carapace.ActionFiles(),
+		"type": carapace.ActionValues(issueTypes...),
 		"label": carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
 			project, _, err := parseArgsRemoteAndProject(c.Args)
 			if err != nil {
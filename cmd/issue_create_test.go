@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
 func Test_issueCreate(t *testing.T) {
@@ -59,7 +60,7 @@ func Test_issueMsg(t *testing.T) {
 		t.Run(test.Name, func(t *testing.T) {
 			test := test
 			t.Parallel()
-			title, body, err := issueMsg("default", test.Msgs)
+			title, body, _, err := issueMsg("default", test.Msgs, "")
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -82,3 +83,64 @@ This is the default issue template for lab
 # of text is the title and the rest is the description.`, text)
 
 }
+
+func Test_issueFieldsFromFrontmatter(t *testing.T) {
+	fields, err := issueFieldsFromFrontmatter(map[string]string{
+		"weight":        "3",
+		"due_date":      "2024-07-01",
+		"confidential":  "true",
+		"type":          "Incident",
+		"epic":          "42",
+		"iteration":     "Sprint 12",
+		"time_estimate": "2h",
+		"linked_mr":     "[!15, 16]",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, *fields.weight)
+	assert.Equal(t, "2024-07-01", fields.dueDate.String())
+	assert.True(t, *fields.confidential)
+	assert.Equal(t, "incident", *fields.issueType)
+	assert.Equal(t, 42, *fields.epicID)
+	assert.Equal(t, "Sprint 12", fields.iteration)
+	assert.Equal(t, "2h", fields.timeEstimate)
+	assert.Equal(t, []int{15, 16}, fields.linkedMRs)
+	assert.False(t, fields.empty())
+
+	fields, err = issueFieldsFromFrontmatter(nil)
+	require.NoError(t, err)
+	assert.True(t, fields.empty())
+
+	for _, fm := range []map[string]string{
+		{"weight": "heavy"},
+		{"due_date": "tomorrow"},
+		{"confidential": "maybe"},
+		{"type": "bug"},
+		{"linked_mr": "15, x"},
+		{"unknown": "1"},
+	} {
+		_, err = issueFieldsFromFrontmatter(fm)
+		assert.Error(t, err, fm)
+	}
+}
+
+func Test_issueFieldsMerge(t *testing.T) {
+	fields := issueFields{
+		weight:    gitlab.Int(1),
+		iteration: "Sprint 11",
+		linkedMRs: []int{3},
+	}
+	fields.merge(issueFields{
+		weight:       gitlab.Int(2),
+		confidential: gitlab.Bool(false),
+	})
+	assert.Equal(t, 2, *fields.weight)
+	assert.False(t, *fields.confidential)
+	assert.Equal(t, "Sprint 11", fields.iteration)
+	assert.Equal(t, []int{3}, fields.linkedMRs)
+}
+
+func Test_issueIDFromURL(t *testing.T) {
+	id, err := issueIDFromURL("https://gitlab.com/zaquestion/test/-/issues/12")
+	require.NoError(t, err)
+	assert.Equal(t, 12, id)
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// issueBranchTemplate is the default template of the branch names
+const issueBranchTemplate = "{{.IID}}-{{.Slug}}"
+
+// issueBranchSlugMax is the maximum length of the slugged issue title
+const issueBranchSlugMax = 50
+
+var issueDevelopCmd = &cobra.Command{
+	Use:   "develop [remote] <id>",
+	Short: "Start working on an issue in a new branch with a draft merge request",
+	Long: heredoc.Doc(`
+		Create a branch to work on an issue and check it out, then open a
+		draft merge request closing the issue and assigned to you.
+
+		The branch is created locally from the given ref, the default branch
+		of the project otherwise, and pushed to the remote. With --remote the
+		branch is created in the project through the API instead and then
+		fetched. With --no-mr, no merge request is created and the branch is
+		only pushed when --remote is given.
+
+		The branch name is generated from a Go template receiving the issue
+		.IID, .Title and .Slug, the title in lowercase with only letters,
+		digits and dashes. The template can be set in lab.toml:
+
+		  [issue_develop]
+		    branch-template = "issue/{{.IID}}-{{.Slug}}"`),
+	Example: heredoc.Doc(`
+		lab issue develop 12
+		lab issue develop upstream 12 --ref release-1.0
+		lab issue develop 12 -b fix-crash --remote
+		lab issue develop 12 --no-mr`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsRemoteAndID(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if id == 0 {
+			log.Fatal("Specify the <id> of the issue")
+		}
+
+		remote := defaultRemote
+		if len(args) == 2 {
+			// parseArgs above already validated this is a remote
+			remote = args[0]
+		}
+
+		branch, err := cmd.Flags().GetString("branch")
+		if err != nil {
+			log.Fatal(err)
+		}
+		branchTemplate, err := cmd.Flags().GetString("branch-template")
+		if err != nil {
+			log.Fatal(err)
+		}
+		ref, err := cmd.Flags().GetString("ref")
+		if err != nil {
+			log.Fatal(err)
+		}
+		createRemote, err := cmd.Flags().GetBool("remote")
+		if err != nil {
+			log.Fatal(err)
+		}
+		noMR, err := cmd.Flags().GetBool("no-mr")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		issue, err := lab.IssueGet(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+		project, err := lab.FindProject(rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if ref == "" {
+			ref = project.DefaultBranch
+		}
+
+		if branch == "" {
+			branch, err = issueBranchName(branchTemplate, issue)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		err = git.New("show-ref", "--verify", "--quiet", "refs/heads/"+branch).Run()
+		if err == nil {
+			log.Fatalf("branch %s already exists", branch)
+		}
+
+		if createRemote {
+			_, err = lab.BranchCreate(rn, branch, ref)
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = git.New("fetch", remote, branch).Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = git.New("checkout", "-b", branch, "--track", remote+"/"+branch).Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			err = git.New("fetch", remote, ref).Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = git.New("checkout", "-b", branch, "--no-track", "FETCH_HEAD").Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+			if !noMR {
+				err = git.New("push", "--set-upstream", remote, branch).Run()
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+
+		if noMR {
+			return
+		}
+
+		userID, err := lab.UserID()
+		if err != nil {
+			log.Fatal(err)
+		}
+		assignees := []int{userID}
+
+		mrURL, err := lab.MRCreate(rn, &gitlab.CreateMergeRequestOptions{
+			SourceBranch:    &branch,
+			TargetBranch:    &ref,
+			TargetProjectID: &project.ID,
+			Title:           gitlab.String(fmt.Sprintf("Draft: Resolve \"%s\"", issue.Title)),
+			Description:     gitlab.String(fmt.Sprintf("Closes #%d", issue.IID)),
+			AssigneeIDs:     &assignees,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(mrURL)
+	},
+}
+
+// issueBranchName renders the branch name template for the issue
+func issueBranchName(tmpl string, issue *gitlab.Issue) (string, error) {
+	t, err := template.New("branch").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, struct {
+		IID   int
+		Title string
+		Slug  string
+	}{issue.IID, issue.Title, slugify(issue.Title, issueBranchSlugMax)})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// slugify lowercases s and replaces every run of characters other than
+// letters and digits with a single dash, cutting the result at max
+// characters on a dash when possible
+func slugify(s string, max int) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > max {
+		cut := slug[:max]
+		if i := strings.LastIndex(cut, "-"); i > 0 && slug[max] != '-' {
+			cut = cut[:i]
+		}
+		slug = strings.TrimSuffix(cut, "-")
+	}
+	return slug
+}
+
+func init() {
+	issueDevelopCmd.Flags().StringP("branch", "b", "", "name of the branch, instead of the one generated from --branch-template")
+	issueDevelopCmd.Flags().String("branch-template", issueBranchTemplate, "Go template of the branch name")
+	issueDevelopCmd.Flags().StringP("ref", "r", "", "branch to start from and to merge into (default branch by default)")
+	issueDevelopCmd.Flags().Bool("remote", false, "create the branch in the project through the API")
+	issueDevelopCmd.Flags().Bool("no-mr", false, "do not create a merge request")
+	issueCmd.AddCommand(issueDevelopCmd)
+
+	carapace.Gen(issueDevelopCmd).FlagCompletion(carapace.ActionMap{
+		"ref": action.RemoteBranches(-1),
+	})
+	carapace.Gen(issueDevelopCmd).PositionalCompletion(
+		action.Remotes(),
+		action.Issues(issueList),
+	)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_slugify(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		slug string
+	}{
+		{"Fix crash on startup", 50, "fix-crash-on-startup"},
+		{"  [UI] Don't   show *empty* lists!", 50, "ui-don-t-show-empty-lists"},
+		{"Ünïcode title", 50, "n-code-title"},
+		{"one two three", 7, "one-two"},
+		{"one two three", 8, "one-two"},
+		{"one two three", 9, "one-two"},
+		{"abcdefghij", 5, "abcde"},
+		{"!!!", 50, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			assert.Equal(t, test.slug, slugify(test.s, test.max))
+		})
+	}
+}
+
+func Test_issueBranchName(t *testing.T) {
+	issue := &gitlab.Issue{IID: 12, Title: "Fix crash on startup"}
+
+	name, err := issueBranchName(issueBranchTemplate, issue)
+	require.NoError(t, err)
+	assert.Equal(t, "12-fix-crash-on-startup", name)
+
+	name, err = issueBranchName("issue/{{.IID}}", issue)
+	require.NoError(t, err)
+	assert.Equal(t, "issue/12", name)
+
+	_, err = issueBranchName("{{.Unknown}}", issue)
+	assert.Error(t, err)
+}
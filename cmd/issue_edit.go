@@ -27,7 +27,11 @@ var issueEditCmd = &cobra.Command{
 		lab issue edit 14 -l new_label --unlabel old_label
 		lab issue edit --milestone "NewYear"
 		lab issue edit --force-linebreak
-		lab issue edit --delete-note 14:2065489`),
+		lab issue edit --delete-note 14:2065489
+		lab issue edit 14 --weight 5 --due-date 2024-07-01
+		lab issue edit 14 --confidential=false --type task
+		lab issue edit 14 --iteration "Sprint 12" --time-estimate 4h
+		lab issue edit 14 -F issue.md`),
 	Args:             cobra.MinimumNArgs(1),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -160,6 +164,10 @@ var issueEditCmd = &cobra.Command{
 		if err != nil {
 			log.Fatal(err)
 		}
+		filename, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		title := issue.Title
 		body := issue.Description
@@ -181,11 +189,17 @@ var issueEditCmd = &cobra.Command{
 			})
 		}
 
-		if openEditor {
-			title, body, err = editDescription(issue.Title, issue.Description, msgs, "")
+		if filename != "" && len(msgs) > 0 {
+			log.Fatal("option -F cannot be combined with -m")
+		}
+
+		var frontmatter map[string]string
+		if filename != "" || openEditor {
+			title, body, frontmatter, err = editIssueDescription(issue.Title, issue.Description, msgs, filename)
 			if err != nil {
 				log.Fatal(err)
 			}
+
 			if title == "" {
 				log.Fatal("aborting: empty issue title")
 			}
@@ -195,7 +209,17 @@ var issueEditCmd = &cobra.Command{
 			}
 		}
 
-		abortUpdate := title == issue.Title && body == issue.Description && !labelsChanged && !assigneesChanged && !updateMilestone
+		fields, err := issueFieldsFromFrontmatter(frontmatter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		flagFields, err := issueFieldsFromFlags(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fields.merge(flagFields)
+
+		abortUpdate := title == issue.Title && body == issue.Description && !labelsChanged && !assigneesChanged && !updateMilestone && fields.empty()
 		if abortUpdate {
 			log.Fatal("aborting: no changes")
 		}
@@ -219,11 +243,21 @@ var issueEditCmd = &cobra.Command{
 			opts.MilestoneID = &milestoneID
 		}
 
+		opts.Weight = fields.weight
+		opts.DueDate = fields.dueDate
+		opts.Confidential = fields.confidential
+		opts.IssueType = fields.issueType
+		opts.EpicID = fields.epicID
+
 		issueURL, err := lab.IssueUpdate(rn, issueNum, opts)
 		if err != nil {
 			log.Fatal(err)
 		}
 		fmt.Println(issueURL)
+		err = fields.applyAfterUpdate(rn, issueNum)
+		if err != nil {
+			log.Fatalf("issue updated, but not all its fields could be set: %s", err)
+		}
 	},
 }
 
@@ -246,6 +280,8 @@ func init() {
 	issueEditCmd.Flags().StringSliceP("assign", "a", []string{}, "add an assignee by username")
 	issueEditCmd.Flags().StringSliceP("unassign", "", []string{}, "remove an assignee by username")
 	issueEditCmd.Flags().String("milestone", "", "set milestone")
+	issueEditCmd.Flags().StringP("file", "F", "", "use the given file as the title, description and frontmatter of the issue")
+	issueAddFieldFlags(issueEditCmd)
 	issueEditCmd.Flags().Bool("force-linebreak", false, "append 2 spaces to the end of each line to force markdown linebreaks")
 	issueEditCmd.Flags().Bool("delete-note", false, "delete the given note; must be provided in <issueID>:<noteID> format")
 	issueEditCmd.Flags().SortFlags = false
@@ -253,6 +289,8 @@ func init() {
 	issueCmd.AddCommand(issueEditCmd)
 
 	carapace.Gen(issueEditCmd).FlagCompletion(carapace.ActionMap{
+		"file": carapace.ActionFiles(),
+		"type": carapace.ActionValues(issueTypes...),
 		"label": carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
 			project, _, err := parseArgsRemoteAndProject(c.Args)
 			if err != nil {
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+)
+
+// issueRecord is an issue as exported and imported by lab
+type issueRecord struct {
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+	Milestone   string   `json:"milestone"`
+	Assignees   []string `json:"assignees"`
+	State       string   `json:"state"`
+	Weight      int      `json:"weight"`
+	DueDate     string   `json:"due_date"`
+}
+
+// issueRecordFields are the columns of the CSV files
+var issueRecordFields = []string{
+	"key", "title", "description", "labels", "milestone",
+	"assignees", "state", "weight", "due_date",
+}
+
+var issueExportCmd = &cobra.Command{
+	Use:   "export [remote] [search]",
+	Short: "Export issues to a CSV or JSON file",
+	Long: heredoc.Doc(`
+		Export the issues matching the given filters, with their title,
+		description, labels, milestone, assignees, state, weight and due date.
+
+		The format is JSON when --format is json or the output file has a
+		.json extension, and CSV otherwise. In CSV files, the labels and the
+		assignees are separated by commas.
+
+		Each issue has a key made of its full reference, used by
+		"lab issue import" to not import an issue twice.`),
+	Example: heredoc.Doc(`
+		lab issue export -o issues.csv
+		lab issue export upstream -l bug --state opened -o bugs.json
+		lab issue export --milestone "1.0" --format json`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			log.Fatal(err)
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatal(err)
+		}
+		format, err = issueRecordsFormat(output, format)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		issueLabels, _ = cmd.Flags().GetStringSlice("label")
+		issueMilestone, _ = cmd.Flags().GetString("milestone")
+		issueAuthor, _ = cmd.Flags().GetString("author")
+		issueAssignee, _ = cmd.Flags().GetString("assignee")
+		issueState, _ = cmd.Flags().GetString("state")
+		issueNumRet = "-1"
+
+		issues, err := issueList(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records := make([]issueRecord, 0, len(issues))
+		for _, issue := range issues {
+			records = append(records, issueToRecord(issue))
+		}
+
+		w := os.Stdout
+		if output != "" {
+			w, err = os.Create(output)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer w.Close()
+		}
+		err = writeIssueRecords(w, records, format)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if output != "" {
+			fmt.Fprintf(os.Stderr, "%d issue(s) exported to %s\n", len(records), output)
+		}
+	},
+}
+
+// issueRecordsFormat returns the format of an issue file, given with
+// --format or guessed from the file extension
+func issueRecordsFormat(filename, format string) (string, error) {
+	if format == "" {
+		format = "csv"
+		if strings.ToLower(filepath.Ext(filename)) == ".json" {
+			format = "json"
+		}
+	}
+	if format != "csv" && format != "json" {
+		return "", errors.Errorf("unknown format %q, use csv or json", format)
+	}
+	return format, nil
+}
+
+func issueToRecord(issue *gitlab.Issue) issueRecord {
+	r := issueRecord{
+		Key:         fmt.Sprintf("#%d", issue.IID),
+		Title:       issue.Title,
+		Description: issue.Description,
+		Labels:      issue.Labels,
+		State:       issue.State,
+		Weight:      issue.Weight,
+		Assignees:   []string{},
+	}
+	if issue.References != nil {
+		r.Key = issue.References.Full
+	}
+	if r.Labels == nil {
+		r.Labels = []string{}
+	}
+	if issue.Milestone != nil {
+		r.Milestone = issue.Milestone.Title
+	}
+	for _, a := range issue.Assignees {
+		r.Assignees = append(r.Assignees, a.Username)
+	}
+	if issue.DueDate != nil {
+		r.DueDate = issue.DueDate.String()
+	}
+	return r
+}
+
+func writeIssueRecords(w io.Writer, records []issueRecord, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+
+	cw := csv.NewWriter(w)
+	err := cw.Write(issueRecordFields)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		weight := ""
+		if r.Weight != 0 {
+			weight = strconv.Itoa(r.Weight)
+		}
+		err = cw.Write([]string{
+			r.Key, r.Title, r.Description, strings.Join(r.Labels, ","),
+			r.Milestone, strings.Join(r.Assignees, ","), r.State, weight,
+			r.DueDate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readIssueRecords(r io.Reader, format string) ([]issueRecord, error) {
+	var records []issueRecord
+	if format == "json" {
+		err := json.NewDecoder(r).Decode(&records)
+		return records, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("missing title column")
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		record := issueRecord{
+			Key:         field("key"),
+			Title:       field("title"),
+			Description: field("description"),
+			Labels:      splitIssueRecordList(field("labels")),
+			Milestone:   field("milestone"),
+			Assignees:   splitIssueRecordList(field("assignees")),
+			State:       field("state"),
+			DueDate:     field("due_date"),
+		}
+		if weight := field("weight"); weight != "" {
+			record.Weight, err = strconv.Atoi(weight)
+			if err != nil {
+				return nil, errors.Errorf("line %d: invalid weight %q", len(records)+2, weight)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// splitIssueRecordList splits a comma separated list of a CSV field
+func splitIssueRecordList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// importKey returns the key identifying the record across imports, its
+// title when it has no key
+func (r issueRecord) importKey() string {
+	if r.Key != "" {
+		return r.Key
+	}
+	return r.Title
+}
+
+// validate checks the fields of the record that don't depend on the
+// project the issue is imported into
+func (r issueRecord) validate() error {
+	if r.Title == "" {
+		return errors.New("empty title")
+	}
+	if r.State != "" && r.State != "opened" && r.State != "closed" {
+		return errors.Errorf("invalid state %q", r.State)
+	}
+	if r.Weight < 0 {
+		return errors.Errorf("invalid weight %d", r.Weight)
+	}
+	if r.DueDate != "" {
+		if _, err := gitlab.ParseISOTime(r.DueDate); err != nil {
+			return errors.Errorf("invalid due date %q, use YYYY-MM-DD", r.DueDate)
+		}
+	}
+	return nil
+}
+
+func init() {
+	issueExportCmd.Flags().StringP("output", "o", "", "write the issues to the given file instead of the standard output")
+	issueExportCmd.Flags().String("format", "", "format of the output: csv or json")
+	issueExportCmd.Flags().StringSliceP("label", "l", []string{}, "export only the issues with the given label(s)")
+	issueExportCmd.Flags().String("milestone", "", "export only the issues of the given milestone/any/none")
+	issueExportCmd.Flags().String("author", "", "export only the issues of the given author")
+	issueExportCmd.Flags().String("assignee", "", "export only the issues assigned to the given user/any/none")
+	issueExportCmd.Flags().StringP("state", "s", "all", "export only the issues in the given state (all/opened/closed)")
+	issueCmd.AddCommand(issueExportCmd)
+
+	carapace.Gen(issueExportCmd).FlagCompletion(carapace.ActionMap{
+		"output":    carapace.ActionFiles(),
+		"format":    carapace.ActionValues("csv", "json"),
+		"label":     bulkLabelCompletion(),
+		"milestone": bulkMilestoneCompletion(),
+		"state":     carapace.ActionValues("all", "opened", "closed"),
+	})
+	carapace.Gen(issueExportCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_issueRecordsFormat(t *testing.T) {
+	format, err := issueRecordsFormat("issues.JSON", "")
+	require.NoError(t, err)
+	assert.Equal(t, "json", format)
+
+	format, err = issueRecordsFormat("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "csv", format)
+
+	format, err = issueRecordsFormat("issues.txt", "json")
+	require.NoError(t, err)
+	assert.Equal(t, "json", format)
+
+	_, err = issueRecordsFormat("issues.xml", "xml")
+	assert.Error(t, err)
+}
+
+func Test_issueToRecord(t *testing.T) {
+	dueDate := gitlab.ISOTime(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+	issue := &gitlab.Issue{
+		IID:         3,
+		Title:       "Crash",
+		Description: "It crashes",
+		Labels:      gitlab.Labels{"bug"},
+		Milestone:   &gitlab.Milestone{Title: "1.0"},
+		Assignees:   []*gitlab.IssueAssignee{{Username: "alice"}},
+		State:       "opened",
+		Weight:      2,
+		DueDate:     &dueDate,
+		References:  &gitlab.IssueReferences{Full: "group/project#3"},
+	}
+
+	assert.Equal(t, issueRecord{
+		Key:         "group/project#3",
+		Title:       "Crash",
+		Description: "It crashes",
+		Labels:      []string{"bug"},
+		Milestone:   "1.0",
+		Assignees:   []string{"alice"},
+		State:       "opened",
+		Weight:      2,
+		DueDate:     "2024-07-01",
+	}, issueToRecord(issue))
+}
+
+func Test_issueRecordsRoundTrip(t *testing.T) {
+	records := []issueRecord{
+		{
+			Key:         "group/project#3",
+			Title:       "Crash, sometimes",
+			Description: "It crashes\n\nwith \"quotes\"",
+			Labels:      []string{"bug", "P1"},
+			Milestone:   "1.0",
+			Assignees:   []string{"alice", "bob"},
+			State:       "closed",
+			Weight:      2,
+			DueDate:     "2024-07-01",
+		},
+		{
+			Key:       "group/project#4",
+			Title:     "Docs",
+			Labels:    []string{},
+			Assignees: []string{},
+			State:     "opened",
+		},
+	}
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			var b bytes.Buffer
+			require.NoError(t, writeIssueRecords(&b, records, format))
+			read, err := readIssueRecords(&b, format)
+			require.NoError(t, err)
+			assert.Equal(t, records, read)
+		})
+	}
+}
+
+func Test_readIssueRecordsCSV(t *testing.T) {
+	records, err := readIssueRecords(strings.NewReader(heredoc.Doc(`
+		Title,Labels,Weight
+		First, "a, b",3
+		Second,,
+	`)), "csv")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "First", records[0].Title)
+	assert.Equal(t, []string{"a", "b"}, records[0].Labels)
+	assert.Equal(t, 3, records[0].Weight)
+	assert.Equal(t, []string{}, records[1].Labels)
+
+	_, err = readIssueRecords(strings.NewReader("labels\nbug\n"), "csv")
+	assert.Error(t, err)
+
+	_, err = readIssueRecords(strings.NewReader("title,weight\nFirst,heavy\n"), "csv")
+	assert.Error(t, err)
+}
+
+func Test_issueRecordValidate(t *testing.T) {
+	assert.NoError(t, issueRecord{Title: "Crash", State: "closed", DueDate: "2024-07-01"}.validate())
+	assert.Error(t, issueRecord{}.validate())
+	assert.Error(t, issueRecord{Title: "Crash", State: "merged"}.validate())
+	assert.Error(t, issueRecord{Title: "Crash", Weight: -1}.validate())
+	assert.Error(t, issueRecord{Title: "Crash", DueDate: "07/01/2024"}.validate())
+}
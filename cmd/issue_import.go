@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// issueImportKeyRegexp matches the import key lab hides in the description
+// of the issues it imports
+var issueImportKeyRegexp = regexp.MustCompile(`<!-- lab-import-key: (.+?) -->`)
+
+var issueImportCmd = &cobra.Command{
+	Use:   "import [remote] <file>",
+	Short: "Create issues from a CSV or JSON file",
+	Long: heredoc.Doc(`
+		Create the issues of a file written by "lab issue export", or of any
+		CSV file with a title column and optionally the other columns of
+		the exported files. The format is guessed as for the export.
+
+		All the issues are validated before creating any of them: the labels,
+		milestones and assignees must exist in the project.
+
+		The key of each issue, or its title when it has none, is hidden in
+		the description of the created issue, so that importing the same
+		file again skips the issues already imported.`),
+	Example: heredoc.Doc(`
+		lab issue import issues.csv
+		lab issue import upstream bugs.json --dry-run`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, filename, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if filename == "" {
+			log.Fatal("Specify the <file> to import")
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatal(err)
+		}
+		format, err = issueRecordsFormat(filename, format)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		f, err := os.Open(filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records, err := readIssueRecords(f, format)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		labels, err := lab.LabelList(rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		milestones, err := lab.MilestoneList(rn, &gitlab.ListMilestonesOptions{
+			IncludeParentMilestones: gitlab.Bool(true),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		users := make(map[string]int)
+
+		var problems []string
+		keys := make(map[string]int)
+		for i, r := range records {
+			problem := func(format string, a ...interface{}) {
+				problems = append(problems, fmt.Sprintf("issue %d (%s): ", i+1, r.Title)+fmt.Sprintf(format, a...))
+			}
+			if err := r.validate(); err != nil {
+				problem("%s", err)
+			}
+			// a key imported twice would create the issue twice
+			if first, ok := keys[r.importKey()]; ok {
+				problem("same key %q as issue %d", r.importKey(), first)
+			} else {
+				keys[r.importKey()] = i + 1
+			}
+			for j, name := range r.Labels {
+				label := issueImportLabel(labels, name)
+				if label == "" {
+					problem("unknown label %q", name)
+					continue
+				}
+				records[i].Labels[j] = label
+			}
+			if r.Milestone != "" && issueImportMilestone(milestones, r.Milestone) == nil {
+				problem("unknown milestone %q", r.Milestone)
+			}
+			for _, username := range r.Assignees {
+				if _, ok := users[username]; ok {
+					continue
+				}
+				id := getUserID(username)
+				if id == nil {
+					problem("unknown user %q", username)
+					continue
+				}
+				users[username] = *id
+			}
+		}
+		if len(problems) > 0 {
+			log.Fatalf("invalid issues, nothing imported:\n%s", strings.Join(problems, "\n"))
+		}
+
+		issues, err := lab.IssueList(rn, gitlab.ListProjectIssuesOptions{
+			State: gitlab.String("all"),
+		}, -1)
+		if err != nil {
+			log.Fatal(err)
+		}
+		imported := make(map[string]int)
+		for _, issue := range issues {
+			if key := issueImportKey(issue.Description); key != "" {
+				imported[key] = issue.IID
+			}
+		}
+
+		created := 0
+		for _, r := range records {
+			key := r.importKey()
+			if id, ok := imported[key]; ok {
+				fmt.Printf("Skipping %s: already imported as #%d\n", key, id)
+				continue
+			}
+			if dryRun {
+				fmt.Printf("Would import %s: %s\n", key, r.Title)
+				continue
+			}
+
+			opts := &gitlab.CreateIssueOptions{
+				Title:       gitlab.String(r.Title),
+				Description: gitlab.String(issueImportDescription(r.Description, key)),
+				Labels:      (*gitlab.LabelOptions)(&r.Labels),
+			}
+			if r.Milestone != "" {
+				opts.MilestoneID = &issueImportMilestone(milestones, r.Milestone).ID
+			}
+			if len(r.Assignees) > 0 {
+				var ids []int
+				for _, username := range r.Assignees {
+					ids = append(ids, users[username])
+				}
+				opts.AssigneeIDs = &ids
+			}
+			if r.Weight != 0 {
+				opts.Weight = gitlab.Int(r.Weight)
+			}
+			if r.DueDate != "" {
+				dueDate, _ := gitlab.ParseISOTime(r.DueDate)
+				opts.DueDate = &dueDate
+			}
+
+			issueURL, err := lab.IssueCreate(rn, opts)
+			if err != nil {
+				log.Fatalf("could not import %s: %s (%d issue(s) imported)", key, err, created)
+			}
+			created++
+			if r.State == "closed" {
+				id, err := issueIDFromURL(issueURL)
+				if err == nil {
+					err = lab.IssueClose(rn, id)
+				}
+				if err != nil {
+					log.Errorln(err)
+				}
+			}
+			fmt.Println(issueURL)
+		}
+	},
+}
+
+// issueImportLabel returns the name of the label matching name, ignoring
+// the case, or an empty string when there is none
+func issueImportLabel(labels []*gitlab.Label, name string) string {
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return l.Name
+		}
+	}
+	return ""
+}
+
+func issueImportMilestone(milestones []*gitlab.Milestone, title string) *gitlab.Milestone {
+	for _, m := range milestones {
+		if m.Title == title {
+			return m
+		}
+	}
+	return nil
+}
+
+// issueImportDescription hides the import key at the end of a description,
+// replacing the key of a previous import
+func issueImportDescription(description, key string) string {
+	description = strings.TrimSpace(issueImportKeyRegexp.ReplaceAllString(description, ""))
+	marker := fmt.Sprintf("<!-- lab-import-key: %s -->", key)
+	if description == "" {
+		return marker
+	}
+	return description + "\n\n" + marker
+}
+
+// issueImportKey returns the import key hidden in a description, if any
+func issueImportKey(description string) string {
+	m := issueImportKeyRegexp.FindStringSubmatch(description)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+func init() {
+	issueImportCmd.Flags().String("format", "", "format of the file: csv or json")
+	issueImportCmd.Flags().Bool("dry-run", false, "validate the file and show the issues that would be created")
+	issueCmd.AddCommand(issueImportCmd)
+
+	carapace.Gen(issueImportCmd).FlagCompletion(carapace.ActionMap{
+		"format": carapace.ActionValues("csv", "json"),
+	})
+	carapace.Gen(issueImportCmd).PositionalCompletion(
+		action.Remotes(),
+		carapace.ActionFiles(),
+	)
+}
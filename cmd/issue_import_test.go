@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_issueImportKey(t *testing.T) {
+	description := issueImportDescription("It crashes", "group/project#3")
+	assert.Equal(t, "It crashes\n\n<!-- lab-import-key: group/project#3 -->", description)
+	assert.Equal(t, "group/project#3", issueImportKey(description))
+
+	description = issueImportDescription(description, "other/project#5")
+	assert.Equal(t, "It crashes\n\n<!-- lab-import-key: other/project#5 -->", description)
+	assert.Equal(t, "other/project#5", issueImportKey(description))
+
+	assert.Equal(t, "<!-- lab-import-key: Docs -->", issueImportDescription("", "Docs"))
+	assert.Equal(t, "", issueImportKey("It crashes"))
+}
+
+func Test_issueRecordImportKey(t *testing.T) {
+	assert.Equal(t, "group/project#3", issueRecord{Key: "group/project#3", Title: "It crashes"}.importKey())
+	assert.Equal(t, "It crashes", issueRecord{Title: "It crashes"}.importKey())
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// issueLinkTypes are the types of issue links, in the order they are shown
+var issueLinkTypes = []string{"relates_to", "blocks", "is_blocked_by"}
+
+var issueLinkCmd = &cobra.Command{
+	Use:   "link [remote] <id> <issue>",
+	Short: "Link an issue to another one",
+	Long: heredoc.Doc(`
+		Link an issue to another issue, given as an issue id of the same
+		project or as a full reference to an issue of another project, like
+		group/project#12. Links of the blocks and is_blocked_by types are
+		only available in GitLab Premium.`),
+	Example: heredoc.Doc(`
+		lab issue link 1 2
+		lab issue link upstream 1 '#2' --type blocks
+		lab issue link 1 group/project#3 --type is_blocked_by`),
+	Args:             cobra.RangeArgs(2, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsRemoteAndID(args[:len(args)-1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if id == 0 {
+			log.Fatal("Cannot determine issue id")
+		}
+
+		linkType, err := cmd.Flags().GetString("type")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !contains(issueLinkTypes, linkType) {
+			log.Fatalf("invalid link type %q, use %s", linkType, strings.Join(issueLinkTypes, ", "))
+		}
+
+		targetProject, targetID, err := parseIssueReference(args[len(args)-1], rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = lab.IssueCreateLink(rn, int(id), targetProject, targetID, linkType)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Issue #%d %s %s\n", id, issueLinkTypeName(linkType), args[len(args)-1])
+	},
+}
+
+// parseIssueReference parses a reference to an issue, either an id of an
+// issue in project, with or without the leading "#", or a full
+// group/project#id reference
+func parseIssueReference(ref, project string) (string, int, error) {
+	if i := strings.LastIndex(ref, "#"); i > 0 {
+		project, ref = ref[:i], ref[i:]
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil || id <= 0 {
+		return "", 0, errors.Errorf("invalid issue reference %s", ref)
+	}
+	return project, id, nil
+}
+
+func issueLinkTypeName(linkType string) string {
+	return strings.Replace(linkType, "_", " ", -1)
+}
+
+// issueLinkRef returns the shortest reference to a linked issue, as seen
+// from an issue of the project projectID
+func issueLinkRef(projectID int, relation *gitlab.IssueRelation) string {
+	if relation.ProjectID != projectID && relation.References != nil {
+		return relation.References.Full
+	}
+	return fmt.Sprintf("#%d", relation.IID)
+}
+
+// issueLinksSummary describes the linked issues grouped by link type, with
+// their state
+func issueLinksSummary(projectID int, relations []*gitlab.IssueRelation) string {
+	types := append([]string{}, issueLinkTypes...)
+	links := make(map[string][]string)
+	for _, r := range relations {
+		if !contains(types, r.LinkType) {
+			types = append(types, r.LinkType)
+		}
+		links[r.LinkType] = append(links[r.LinkType],
+			fmt.Sprintf("%s (%s)", issueLinkRef(projectID, r), r.State))
+	}
+
+	var groups []string
+	for _, t := range types {
+		if len(links[t]) > 0 {
+			groups = append(groups, issueLinkTypeName(t)+" "+strings.Join(links[t], ", "))
+		}
+	}
+	return strings.Join(groups, "; ")
+}
+
+func init() {
+	issueLinkCmd.Flags().StringP("type", "t", "relates_to", "type of the link: relates_to, blocks or is_blocked_by")
+	issueCmd.AddCommand(issueLinkCmd)
+
+	carapace.Gen(issueLinkCmd).FlagCompletion(carapace.ActionMap{
+		"type": carapace.ActionValues(issueLinkTypes...),
+	})
+	carapace.Gen(issueLinkCmd).PositionalCompletion(
+		action.Remotes(),
+		action.Issues(issueList),
+	)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_parseIssueReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		project string
+		id      int
+	}{
+		{"12", "zaquestion/test", 12},
+		{"#12", "zaquestion/test", 12},
+		{"group/project#3", "group/project", 3},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			project, id, err := parseIssueReference(test.ref, "zaquestion/test")
+			require.NoError(t, err)
+			assert.Equal(t, test.project, project)
+			assert.Equal(t, test.id, id)
+		})
+	}
+
+	for _, ref := range []string{"", "#", "abc", "group/project#x", "-1"} {
+		_, _, err := parseIssueReference(ref, "zaquestion/test")
+		assert.Error(t, err, ref)
+	}
+}
+
+func Test_issueLinksSummary(t *testing.T) {
+	relations := []*gitlab.IssueRelation{
+		{IID: 5, State: "opened", ProjectID: 1, LinkType: "blocks"},
+		{IID: 3, State: "opened", ProjectID: 1, LinkType: "relates_to"},
+		{IID: 4, State: "closed", ProjectID: 2, LinkType: "relates_to",
+			References: &gitlab.IssueReferences{Full: "group/project#4"}},
+	}
+
+	assert.Equal(t, "relates to #3 (opened), group/project#4 (closed); blocks #5 (opened)",
+		issueLinksSummary(1, relations))
+	assert.Equal(t, "", issueLinksSummary(1, nil))
+}
@@ -28,6 +28,7 @@ var (
 	issueAuthorID   *int
 	issueOrder      string
 	issueSortedBy   string
+	issueGroup      string
 )
 
 var issueListCmd = &cobra.Command{
@@ -47,7 +48,9 @@ var issueListCmd = &cobra.Command{
 		lab issue list remote -n "10"
 		lab issue list remote --order "created_at"
 		lab issue list remote --sort "asc"
-		lab issue list remote --state "closed"`),
+		lab issue list remote --state "closed"
+		lab issue list --group
+		lab issue list --group=my-group/sub-group -l bug`),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		issues, err := issueList(args)
@@ -59,6 +62,10 @@ var issueListCmd = &cobra.Command{
 		defer pager.Close()
 
 		for _, issue := range issues {
+			if issueGroup != "" {
+				fmt.Printf("%s %s\n", issueFullReference(issue), issue.Title)
+				continue
+			}
 			fmt.Printf("#%d %s\n", issue.IID, issue.Title)
 		}
 	},
@@ -71,16 +78,21 @@ func issueList(args []string) ([]*gitlab.Issue, error) {
 	}
 	issueSearch = search
 
-	labels, err := mapLabelsAsLabelOptions(rn, issueLabels)
-	if err != nil {
-		return nil, err
+	// the labels and milestones of a group can't be matched against the
+	// ones of the project, so they are used as given
+	labels := gitlab.LabelOptions(issueLabels)
+	if issueGroup == "" {
+		labels, err = mapLabelsAsLabelOptions(rn, issueLabels)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if strings.ToLower(issueMilestone) == "any" {
 		issueMilestone = "Any"
 	} else if strings.ToLower(issueMilestone) == "none" {
 		issueMilestone = "None"
-	} else if issueMilestone != "" {
+	} else if issueMilestone != "" && issueGroup == "" {
 		milestone, err := lab.MilestoneGet(rn, issueMilestone)
 		if err != nil {
 			return nil, err
@@ -148,6 +160,24 @@ func issueList(args []string) ([]*gitlab.Issue, error) {
 		opts.Search = &issueSearch
 	}
 
+	if issueGroup != "" {
+		group, err := resolveGroup(issueGroup, rn)
+		if err != nil {
+			return nil, err
+		}
+		return lab.GroupIssueList(group.ID, gitlab.ListGroupIssuesOptions{
+			ListOptions: opts.ListOptions,
+			Labels:      opts.Labels,
+			Milestone:   opts.Milestone,
+			State:       opts.State,
+			OrderBy:     opts.OrderBy,
+			Sort:        opts.Sort,
+			AuthorID:    opts.AuthorID,
+			AssigneeID:  opts.AssigneeID,
+			Search:      opts.Search,
+		}, num)
+	}
+
 	return lab.IssueList(rn, opts, num)
 }
 
@@ -178,6 +208,10 @@ func init() {
 		"match on the exact (case-insensitive) search terms")
 	issueListCmd.Flags().StringVar(&issueOrder, "order", "updated_at", "display order (updated_at/created_at)")
 	issueListCmd.Flags().StringVar(&issueSortedBy, "sort", "desc", "sort order (desc/asc)")
+	issueListCmd.Flags().StringVar(
+		&issueGroup, "group", "",
+		"list the issues of the given group, or of the group of the project when no group is given")
+	issueListCmd.Flags().Lookup("group").NoOptDefVal = groupCurrent
 
 	issueCmd.AddCommand(issueListCmd)
 	carapace.Gen(issueListCmd).FlagCompletion(carapace.ActionMap{
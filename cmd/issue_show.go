@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
@@ -84,7 +86,49 @@ var issueShowCmd = &cobra.Command{
 	},
 }
 
+// issueDetails is what is shown of an issue besides the issue itself
+type issueDetails struct {
+	relatedMRs   []int
+	closingMRs   []int
+	linkedIssues string
+}
+
+// getIssueDetails fetches the details of an issue shown by printIssue
+func getIssueDetails(project string, issue *gitlab.Issue) (*issueDetails, error) {
+	var (
+		details issueDetails
+		err     error
+	)
+	details.relatedMRs, err = lab.ListMRsRelatedToIssue(project, issue.IID)
+	if err != nil {
+		return nil, err
+	}
+	details.closingMRs, err = lab.ListMRsClosingIssue(project, issue.IID)
+	if err != nil {
+		return nil, err
+	}
+
+	relations, err := lab.IssueListLinks(project, issue.IID)
+	if err != nil {
+		log.Debugln(err)
+	}
+	details.linkedIssues = issueLinksSummary(issue.ProjectID, relations)
+	return &details, nil
+}
+
 func printIssue(issue *gitlab.Issue, project string, renderMarkdown bool) {
+	details, err := getIssueDetails(project, issue)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = writeIssue(os.Stdout, issue, project, details, renderMarkdown)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeIssue writes an issue and its details as printed by lab issue show
+func writeIssue(w io.Writer, issue *gitlab.Issue, project string, details *issueDetails, renderMarkdown bool) error {
 	milestone := "None"
 	timestats := "None"
 	dueDate := "None"
@@ -116,25 +160,16 @@ func printIssue(issue *gitlab.Issue, project string, renderMarkdown bool) {
 	if renderMarkdown {
 		r, err := getTermRenderer(glamour.WithAutoStyle())
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		issue.Description, _ = r.Render(issue.Description)
 	}
 
-	relatedMRs, err := lab.ListMRsRelatedToIssue(project, issue.IID)
-	if err != nil {
-		log.Fatal(err)
-	}
-	closingMRs, err := lab.ListMRsClosingIssue(project, issue.IID)
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	if issue.Subscribed {
 		subscribed = "Yes"
 	}
 
-	fmt.Printf(
+	_, err := fmt.Fprintf(w,
 		heredoc.Doc(`#%d %s
 			===================================
 			%s
@@ -149,16 +184,18 @@ func printIssue(issue *gitlab.Issue, project string, renderMarkdown bool) {
 			Labels: %s
 			Related MRs: %s
 			MRs that will close this Issue: %s
+			Linked Issues: %s
 			Subscribed: %s
 			WebURL: %s
 		`),
 		issue.IID, issue.Title, issue.Description, project, state, strings.Join(assignees, ", "),
 		issue.Author.Username, milestone, dueDate, timestats,
 		strings.Join(issue.Labels, ", "),
-		strings.Trim(strings.Replace(fmt.Sprint(relatedMRs), " ", ",", -1), "[]"),
-		strings.Trim(strings.Replace(fmt.Sprint(closingMRs), " ", ",", -1), "[]"),
-		subscribed, issue.WebURL,
+		strings.Trim(strings.Replace(fmt.Sprint(details.relatedMRs), " ", ",", -1), "[]"),
+		strings.Trim(strings.Replace(fmt.Sprint(details.closingMRs), " ", ",", -1), "[]"),
+		details.linkedIssues, subscribed, issue.WebURL,
 	)
+	return err
 }
 
 func init() {
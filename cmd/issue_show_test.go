@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"bytes"
 	"os/exec"
 	"testing"
 
 	"github.com/acarl005/stripansi"
 	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
 func Test_issueShow(t *testing.T) {
@@ -35,6 +37,7 @@ Time Stats: Estimated 40h, Spent 8h
 Labels: bug
 Related MRs: 1
 MRs that will close this Issue: 
+Linked Issues: 
 Subscribed: No
 WebURL: https://gitlab.com/zaquestion/test/-/issues/1
 `)
@@ -57,3 +60,25 @@ func Test_issueShow_updated_comments(t *testing.T) {
 
 	require.Contains(t, string(out), `updated comment at`)
 }
+
+func Test_writeIssue(t *testing.T) {
+	issue := &gitlab.Issue{
+		IID:         7,
+		Title:       "Some issue",
+		Description: "Some description",
+		State:       "opened",
+		Author:      &gitlab.IssueAuthor{Username: "janedoe"},
+		Labels:      gitlab.Labels{"bug"},
+		WebURL:      "https://gitlab.com/zaquestion/test/-/issues/7",
+	}
+	details := &issueDetails{relatedMRs: []int{3, 4}, closingMRs: []int{4}, linkedIssues: "None"}
+
+	var b bytes.Buffer
+	require.NoError(t, writeIssue(&b, issue, "zaquestion/test", details, false))
+	out := b.String()
+	require.Contains(t, out, "#7 Some issue")
+	require.Contains(t, out, "Author: janedoe")
+	require.Contains(t, out, "Related MRs: 3,4")
+	require.Contains(t, out, "MRs that will close this Issue: 4")
+	require.Contains(t, out, "WebURL: https://gitlab.com/zaquestion/test/-/issues/7")
+}
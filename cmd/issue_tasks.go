@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// issueTasks reads and writes the tasks of issues
+var issueTasks = tasksTarget{
+	get: func(rn string, id int) (string, *time.Time, error) {
+		issue, err := lab.IssueGet(rn, id)
+		if err != nil {
+			return "", nil, err
+		}
+		return issue.Description, issue.UpdatedAt, nil
+	},
+	update: func(rn string, id int, description string) error {
+		_, err := lab.IssueUpdate(rn, id, &gitlab.UpdateIssueOptions{
+			Description: &description,
+		})
+		return err
+	},
+}
+
+var issueTasksCmd = &cobra.Command{
+	Use:   "tasks [remote] <id>",
+	Short: "List the tasks of an issue",
+	Long: heredoc.Doc(`
+		List the items of the task lists in the description of an issue,
+		with the index used to check or uncheck them.
+
+		The time the description was last updated is printed too; give it
+		to check or uncheck with --expect-updated-at to refuse the change
+		if the description was edited since.`),
+	Example: heredoc.Doc(`
+		lab issue tasks 12
+		lab issue tasks check 12 1 3
+		lab issue tasks uncheck upstream 12 2
+		lab issue tasks check 12 1 --expect-updated-at 2024-06-30T12:00:00.123Z`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsRemoteAndID(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if id == 0 {
+			log.Fatal("Specify the <id> of the issue")
+		}
+
+		description, updatedAt, err := issueTasks.get(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+		printTasks(parseTasks(description), updatedAt)
+	},
+}
+
+var issueTasksCheckCmd = &cobra.Command{
+	Use:              "check [remote] <id> <index>...",
+	Short:            "Check tasks of an issue",
+	Args:             cobra.MinimumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueTasksToggle(cmd, args, true)
+	},
+}
+
+var issueTasksUncheckCmd = &cobra.Command{
+	Use:              "uncheck [remote] <id> <index>...",
+	Short:            "Uncheck tasks of an issue",
+	Args:             cobra.MinimumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueTasksToggle(cmd, args, false)
+	},
+}
+
+func runIssueTasksToggle(cmd *cobra.Command, args []string, checked bool) {
+	rn, id, indices, err := parseTasksArgs(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+	expected, err := tasksExpectedUpdatedAt(cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = toggleTasks(issueTasks, rn, id, indices, checked, expected)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	description, updatedAt, err := issueTasks.get(rn, id)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Issue #%d:\n", id)
+	printTasks(parseTasks(description), updatedAt)
+}
+
+func init() {
+	tasksAddFlags(issueTasksCheckCmd)
+	tasksAddFlags(issueTasksUncheckCmd)
+	issueTasksCmd.AddCommand(issueTasksCheckCmd)
+	issueTasksCmd.AddCommand(issueTasksUncheckCmd)
+	issueCmd.AddCommand(issueTasksCmd)
+
+	for _, cmd := range []*cobra.Command{issueTasksCmd, issueTasksCheckCmd, issueTasksUncheckCmd} {
+		carapace.Gen(cmd).PositionalCompletion(
+			action.Remotes(),
+			action.Issues(issueList),
+		)
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+)
+
+var issueTimeCmd = &cobra.Command{
+	Use:              "time",
+	Short:            "Time tracking of issues",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var issueTimeEstimateCmd = &cobra.Command{
+	Use:   "estimate [remote] <id> <duration>",
+	Short: "Set the time estimate of an issue",
+	Example: heredoc.Doc(`
+		lab issue time estimate 1 3h
+		lab issue time estimate origin 1 "1d 4h"`),
+	Args:             cobra.RangeArgs(2, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeEstimateRunFn,
+}
+
+var issueTimeSpendCmd = &cobra.Command{
+	Use:   "spend [remote] <id> <duration>",
+	Short: "Add time spent on an issue",
+	Long: heredoc.Doc(`
+		Add time spent on an issue, using GitLab durations like 1h30m or
+		"1d 2h". Negative durations, like -30m, subtract time.`),
+	Example: heredoc.Doc(`
+		lab issue time spend 1 1h30m
+		lab issue time spend origin 1 2h --summary "Investigation"
+		lab issue time spend origin 1 -- -30m
+		lab issue time spend 1 1h --date 2023-05-01`),
+	Args:             cobra.RangeArgs(2, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeSpendRunFn,
+}
+
+var issueTimeResetCmd = &cobra.Command{
+	Use:   "reset [remote] <id>",
+	Short: "Reset the time estimate and time spent of an issue",
+	Example: heredoc.Doc(`
+		lab issue time reset 1
+		lab issue time reset origin 1 --spent`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeResetRunFn,
+}
+
+var issueTimeReportCmd = &cobra.Command{
+	Use:   "report [remote]",
+	Short: "Report the time spent per user on issues",
+	Long: heredoc.Doc(`
+		Report the time spent per user on the issues of a milestone or with
+		the given labels, based on the time tracking notes of each issue.`),
+	Example: heredoc.Doc(`
+		lab issue time report --milestone 1.0
+		lab issue time report origin --label bug --label backend`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeReportRunFn,
+}
+
+func init() {
+	issueTimeSpendCmd.Flags().String("summary", "", "summary of the work done")
+	issueTimeSpendCmd.Flags().String("date", "", "date the time was spent at, in the YYYY-MM-DD format")
+	issueTimeResetCmd.Flags().Bool("estimate", false, "only reset the time estimate")
+	issueTimeResetCmd.Flags().Bool("spent", false, "only reset the time spent")
+	issueTimeReportCmd.Flags().String("milestone", "", "report the issues of the given milestone")
+	issueTimeReportCmd.Flags().StringSliceP("label", "l", []string{}, "report the issues with the given labels")
+
+	issueTimeCmd.AddCommand(issueTimeEstimateCmd)
+	issueTimeCmd.AddCommand(issueTimeSpendCmd)
+	issueTimeCmd.AddCommand(issueTimeResetCmd)
+	issueTimeCmd.AddCommand(issueTimeReportCmd)
+	issueCmd.AddCommand(issueTimeCmd)
+
+	for _, cmd := range []*cobra.Command{issueTimeEstimateCmd, issueTimeSpendCmd, issueTimeResetCmd} {
+		carapace.Gen(cmd).PositionalCompletion(
+			action.Remotes(),
+			action.Issues(issueList),
+		)
+	}
+	carapace.Gen(issueTimeReportCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var issueUnlinkCmd = &cobra.Command{
+	Use:   "unlink [remote] <id> <issue>",
+	Short: "Remove the link between two issues",
+	Example: heredoc.Doc(`
+		lab issue unlink 1 2
+		lab issue unlink upstream 1 group/project#3`),
+	Args:             cobra.RangeArgs(2, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsRemoteAndID(args[:len(args)-1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if id == 0 {
+			log.Fatal("Cannot determine issue id")
+		}
+
+		targetProject, targetID, err := parseIssueReference(args[len(args)-1], rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		target, err := lab.FindProject(targetProject)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		relations, err := lab.IssueListLinks(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, r := range relations {
+			if r.ProjectID != target.ID || r.IID != targetID {
+				continue
+			}
+			err = lab.IssueDeleteLink(rn, int(id), r.IssueLinkID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Issue #%d no longer %s %s\n", id, issueLinkTypeName(r.LinkType), args[len(args)-1])
+			return
+		}
+		log.Fatalf("Issue #%d is not linked to %s", id, args[len(args)-1])
+	},
+}
+
+func init() {
+	issueCmd.AddCommand(issueUnlinkCmd)
+	carapace.Gen(issueUnlinkCmd).PositionalCompletion(
+		action.Remotes(),
+		action.Issues(issueList),
+	)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var issueWatchCmd = &cobra.Command{
+	Use:   "watch [remote] <id>",
+	Short: "Watch an issue for new activity",
+	Long: heredoc.Doc(`
+		Check an issue at regular intervals and print its new notes and
+		state changes as they appear, until interrupted.
+
+		The command given with --exec is run by the shell for each event,
+		with the LAB_EVENT variable set to its kind and LAB_EVENT_TEXT to
+		its description. With --notify, a desktop notification is shown
+		with notify-send. Both can be limited to some kinds of events with
+		--on.`),
+	Example: heredoc.Doc(`
+		lab issue watch 14
+		lab issue watch upstream 14 --notify --on state`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsRemoteAndID(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if id == 0 {
+			log.Fatal("Cannot determine issue id")
+		}
+		opts, err := watchOptionsFromFlags(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		snapshot := func() (*watchSnapshot, error) {
+			return issueWatchSnapshot(rn, int(id))
+		}
+		err = watch(cmd.Context(), fmt.Sprintf("%s#%d", rn, id), snapshot, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func issueWatchSnapshot(rn string, id int) (*watchSnapshot, error) {
+	issue, err := lab.IssueGet(rn, id)
+	if err != nil {
+		return nil, err
+	}
+	discussions, err := lab.IssueListDiscussions(rn, id)
+	if err != nil {
+		return nil, err
+	}
+	return &watchSnapshot{
+		State: issue.State,
+		Notes: watchNotes(discussions),
+	}, nil
+}
+
+func init() {
+	watchAddFlags(issueWatchCmd)
+	issueCmd.AddCommand(issueWatchCmd)
+
+	carapace.Gen(issueWatchCmd).FlagCompletion(carapace.ActionMap{
+		"on": carapace.ActionValues(watchEventKinds...).UniqueList(","),
+	})
+	carapace.Gen(issueWatchCmd).PositionalCompletion(
+		action.Remotes(),
+		action.Issues(issueList),
+	)
+}
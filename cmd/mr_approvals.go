@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/fatih/color"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// mrApprovalRule is the summary of a single approval rule of a merge request
+type mrApprovalRule struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Section           string   `json:"section,omitempty"`
+	CodeOwner         bool     `json:"code_owner"`
+	ApprovalsRequired int      `json:"approvals_required"`
+	Approved          bool     `json:"approved"`
+	EligibleApprovers []string `json:"eligible_approvers"`
+	ApprovedBy        []string `json:"approved_by"`
+}
+
+// mrApprovals is the summary of the approval state of a merge request
+type mrApprovals struct {
+	IID                 int              `json:"iid"`
+	Approved            bool             `json:"approved"`
+	ApprovalsRequired   int              `json:"approvals_required"`
+	ApprovalsLeft       int              `json:"approvals_left"`
+	ApprovedBy          []string         `json:"approved_by"`
+	RulesOverwritten    bool             `json:"approval_rules_overwritten"`
+	Rules               []mrApprovalRule `json:"rules"`
+	Mergeable           bool             `json:"mergeable"`
+	DetailedMergeStatus string           `json:"detailed_merge_status"`
+}
+
+var mrApprovalsCmd = &cobra.Command{
+	Use:   "approvals [remote] [<MR id or branch>]",
+	Short: "Show the approval rules and approval state of a merge request",
+	Long: heredoc.Doc(`
+		Show the approval rules of a merge request, including code owner
+		rules, with the number of approvals required by each one of them,
+		the eligible approvers and who already approved it.
+
+		The command exits with status 0 when the merge request has all the
+		approvals it needs and with status 1 otherwise.`),
+	Example: heredoc.Doc(`
+		lab mr approvals
+		lab mr approvals origin 18
+		lab mr approvals upstream my_branch --json`),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		jsonOutput, err := cmd.Flags().GetBool("json")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mr, err := lab.MRGet(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		approvalConfig, err := lab.GetMRApprovalsConfiguration(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		approvalState, err := lab.GetMRApprovalState(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		approvals := summarizeMRApprovals(mr, approvalConfig, approvalState)
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(approvals, "", "  ")
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(string(out))
+		} else {
+			pager := newPager(cmd.Flags())
+			printMRApprovals(approvals)
+			pager.Close()
+		}
+
+		// exit w/ status code 1 to indicate approvals are still missing
+		if !approvals.Approved {
+			os.Exit(1)
+		}
+	},
+}
+
+// summarizeMRApprovals merges the information from the MR itself, its
+// approval configuration and the state of its approval rules
+func summarizeMRApprovals(mr *gitlab.MergeRequest, config *gitlab.MergeRequestApprovals, state *gitlab.MergeRequestApprovalState) mrApprovals {
+	approvals := mrApprovals{
+		IID:                 mr.IID,
+		Approved:            config.Approved && config.ApprovalsLeft == 0,
+		ApprovalsRequired:   config.ApprovalsRequired,
+		ApprovalsLeft:       config.ApprovalsLeft,
+		ApprovedBy:          []string{},
+		Rules:               []mrApprovalRule{},
+		Mergeable:           mr.DetailedMergeStatus == "mergeable",
+		DetailedMergeStatus: mr.DetailedMergeStatus,
+	}
+
+	for _, approver := range config.ApprovedBy {
+		approvals.ApprovedBy = append(approvals.ApprovedBy, approver.User.Username)
+	}
+
+	if state == nil {
+		return approvals
+	}
+	approvals.RulesOverwritten = state.ApprovalRulesOverwritten
+
+	for _, rule := range state.Rules {
+		r := mrApprovalRule{
+			Name:              rule.Name,
+			Type:              rule.RuleType,
+			Section:           rule.Section,
+			CodeOwner:         rule.RuleType == "code_owner",
+			ApprovalsRequired: rule.ApprovalsRequired,
+			Approved:          rule.Approved,
+			EligibleApprovers: []string{},
+			ApprovedBy:        []string{},
+		}
+		for _, u := range rule.EligibleApprovers {
+			r.EligibleApprovers = append(r.EligibleApprovers, u.Username)
+		}
+		for _, u := range rule.ApprovedBy {
+			r.ApprovedBy = append(r.ApprovedBy, u.Username)
+		}
+		approvals.Rules = append(approvals.Rules, r)
+	}
+
+	return approvals
+}
+
+func printMRApprovals(approvals mrApprovals) {
+	joinOrNone := func(s []string) string {
+		if len(s) == 0 {
+			return "None"
+		}
+		return strings.Join(s, ", ")
+	}
+
+	approved := color.RedString("No")
+	if approvals.Approved {
+		approved = color.GreenString("Yes")
+	}
+	mergeable := color.RedString("No (%s)", strings.Replace(approvals.DetailedMergeStatus, "_", " ", -1))
+	if approvals.Mergeable {
+		mergeable = color.GreenString("Yes")
+	}
+
+	fmt.Printf(
+		heredoc.Doc(`
+			!%d
+			-----------------------------------
+			Approved: %s
+			Approvals: %d/%d (%d left)
+			Approved By: %s
+			Mergeable: %s
+		`),
+		approvals.IID, approved,
+		approvals.ApprovalsRequired-approvals.ApprovalsLeft,
+		approvals.ApprovalsRequired, approvals.ApprovalsLeft,
+		joinOrNone(approvals.ApprovedBy), mergeable,
+	)
+
+	if len(approvals.Rules) == 0 {
+		return
+	}
+
+	fmt.Println("Rules:")
+	if approvals.RulesOverwritten {
+		fmt.Println("  (project rules overwritten for this merge request)")
+	}
+	for _, rule := range approvals.Rules {
+		status := color.RedString("✘")
+		if rule.Approved {
+			status = color.GreenString("✔")
+		}
+
+		name := rule.Name
+		if rule.CodeOwner && rule.Section != "" && rule.Section != "codeowners" {
+			name = fmt.Sprintf("[%s] %s", rule.Section, name)
+		}
+
+		fmt.Printf("  %s %s (%s) %d/%d\n", status, name, rule.Type,
+			len(rule.ApprovedBy), rule.ApprovalsRequired)
+		fmt.Printf("      Eligible Approvers: %s\n", joinOrNone(rule.EligibleApprovers))
+		fmt.Printf("      Approved By: %s\n", joinOrNone(rule.ApprovedBy))
+	}
+}
+
+func init() {
+	mrApprovalsCmd.Flags().Bool("json", false, "print the approval state in JSON format")
+	mrCmd.AddCommand(mrApprovalsCmd)
+	carapace.Gen(mrApprovalsCmd).PositionalCompletion(
+		action.Remotes(),
+		action.MergeRequests(mrList),
+	)
+}
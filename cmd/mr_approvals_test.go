@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_summarizeMRApprovals(t *testing.T) {
+	mr := &gitlab.MergeRequest{
+		BasicMergeRequest: gitlab.BasicMergeRequest{
+			IID:                 18,
+			DetailedMergeStatus: "not_approved",
+		},
+	}
+	config := &gitlab.MergeRequestApprovals{
+		Approved:          false,
+		ApprovalsRequired: 2,
+		ApprovalsLeft:     1,
+		ApprovedBy: []*gitlab.MergeRequestApproverUser{
+			{User: &gitlab.BasicUser{Username: "alice"}},
+		},
+	}
+	state := &gitlab.MergeRequestApprovalState{
+		Rules: []*gitlab.MergeRequestApprovalRule{
+			{
+				Name:              "Maintainers",
+				RuleType:          "regular",
+				ApprovalsRequired: 1,
+				Approved:          true,
+				EligibleApprovers: []*gitlab.BasicUser{{Username: "alice"}, {Username: "bob"}},
+				ApprovedBy:        []*gitlab.BasicUser{{Username: "alice"}},
+			},
+			{
+				Name:              "*.go",
+				RuleType:          "code_owner",
+				Section:           "Backend",
+				ApprovalsRequired: 1,
+				EligibleApprovers: []*gitlab.BasicUser{{Username: "carol"}},
+			},
+		},
+	}
+
+	approvals := summarizeMRApprovals(mr, config, state)
+	assert.Equal(t, 18, approvals.IID)
+	assert.False(t, approvals.Approved)
+	assert.False(t, approvals.Mergeable)
+	assert.Equal(t, 1, approvals.ApprovalsLeft)
+	assert.Equal(t, []string{"alice"}, approvals.ApprovedBy)
+	assert.Len(t, approvals.Rules, 2)
+
+	assert.False(t, approvals.Rules[0].CodeOwner)
+	assert.True(t, approvals.Rules[0].Approved)
+	assert.Equal(t, []string{"alice", "bob"}, approvals.Rules[0].EligibleApprovers)
+
+	assert.True(t, approvals.Rules[1].CodeOwner)
+	assert.Equal(t, "Backend", approvals.Rules[1].Section)
+	assert.Equal(t, []string{}, approvals.Rules[1].ApprovedBy)
+
+	config.Approved = true
+	config.ApprovalsLeft = 0
+	mr.DetailedMergeStatus = "mergeable"
+	approvals = summarizeMRApprovals(mr, config, nil)
+	assert.True(t, approvals.Approved)
+	assert.True(t, approvals.Mergeable)
+	assert.Empty(t, approvals.Rules)
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// refs used to hold the fetched commits during a local backport
+const (
+	backportBaseRef   = "refs/lab/backport/base"
+	backportMRRef     = "refs/lab/backport/mr"
+	backportTargetRef = "refs/lab/backport/target"
+)
+
+// mrBackportResult is the outcome of backporting a MR to a single branch
+type mrBackportResult struct {
+	branch    string
+	url       string
+	conflicts []string
+	err       error
+}
+
+var mrBackportCmd = &cobra.Command{
+	Use:   "backport [remote] [<MR id or branch>] --to <branch>...",
+	Short: "Cherry-pick a merged merge request onto other branches",
+	Long: heredoc.Doc(`
+		Backport a merged merge request by cherry-picking its commits, or
+		its squash commit, onto a new branch created from each of the given
+		branches and opening one merge request per branch. A note linking
+		the backports is added to the original merge request.
+
+		Commits are cherry-picked using the GitLab API. When that fails
+		because of conflicts, the cherry-pick is retried locally in a
+		temporary worktree. Branches that still conflict are reported and
+		the command exits with status 1.`),
+	Example: heredoc.Doc(`
+		lab mr backport 10 --to release-1.0
+		lab mr backport upstream 10 --to release-1.0 --to release-1.1
+		lab mr backport upstream 10 --to release-1.0,release-1.1 -l backport`),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		targets, err := cmd.Flags().GetStringSlice("to")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(targets) == 0 {
+			log.Fatal("at least one target branch must be given with --to")
+		}
+		labelTerms, err := cmd.Flags().GetStringSlice("label")
+		if err != nil {
+			log.Fatal(err)
+		}
+		labels, err := mapLabelsAsLabelOptions(rn, labelTerms)
+		if err != nil {
+			log.Fatal(err)
+		}
+		draft, err := cmd.Flags().GetBool("draft")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mr, err := lab.MRGet(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if mr.State != "merged" {
+			log.Fatalf("Merge Request !%d is not merged", id)
+		}
+
+		commits, err := mrBackportCommits(rn, mr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		project, err := lab.GetProject(mr.TargetProjectID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		failed := false
+		for _, target := range targets {
+			res := mrBackport(rn, project, mr, commits, target)
+			if res.err == nil && len(res.conflicts) == 0 {
+				res.url, res.err = mrBackportCreate(rn, mr, commits, target, res.branch, labels, draft)
+			}
+
+			switch {
+			case len(res.conflicts) > 0:
+				failed = true
+				fmt.Printf("%s: conflicts in %s\n", target, strings.Join(res.conflicts, ", "))
+			case res.err != nil:
+				failed = true
+				fmt.Printf("%s: %s\n", target, res.err)
+			default:
+				fmt.Printf("%s: %s\n", target, res.url)
+			}
+		}
+
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// mrBackportCommits returns the commits to cherry-pick, oldest first: the
+// squash commit of the MR when there's one, or all of its commits
+func mrBackportCommits(rn string, mr *gitlab.MergeRequest) ([]string, error) {
+	if mr.SquashCommitSHA != "" {
+		return []string{mr.SquashCommitSHA}, nil
+	}
+
+	mrCommits, err := lab.MRListCommits(rn, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mrCommits) == 0 {
+		return nil, fmt.Errorf("no commits found for Merge Request !%d", mr.IID)
+	}
+
+	commits := make([]string, len(mrCommits))
+	for i, c := range mrCommits {
+		commits[len(mrCommits)-1-i] = c.ID
+	}
+	return commits, nil
+}
+
+// mrBackport creates the backport branch for target, using the API first
+// and falling back to a local cherry-pick
+func mrBackport(rn string, project *gitlab.Project, mr *gitlab.MergeRequest, commits []string, target string) mrBackportResult {
+	res := mrBackportResult{
+		branch: fmt.Sprintf("backport-%d-to-%s", mr.IID, strings.Replace(target, "/", "-", -1)),
+	}
+
+	_, err := lab.BranchCreate(rn, res.branch, target)
+	if err != nil {
+		res.err = err
+		return res
+	}
+
+	for _, sha := range commits {
+		_, err = lab.CommitCherryPick(rn, sha, res.branch)
+		if err == nil {
+			continue
+		}
+
+		log.Debugf("could not cherry-pick %s onto %s: %s", sha, target, err)
+		if err := lab.BranchDelete(rn, res.branch); err != nil {
+			res.err = err
+			return res
+		}
+		res.conflicts, res.err = mrBackportLocal(labURLToRepo(project), mr, commits, target, res.branch)
+		return res
+	}
+	return res
+}
+
+// mrBackportLocal cherry-picks the commits in a temporary worktree and
+// pushes the result as branch. It returns the conflicting files, if any.
+func mrBackportLocal(url string, mr *gitlab.MergeRequest, commits []string, target, branch string) ([]string, error) {
+	gitQuiet := func(args ...string) ([]byte, error) {
+		cmd := git.New(args...)
+		cmd.Stdout = nil
+		cmd.Stderr = nil
+		return cmd.Output()
+	}
+
+	_, err := gitQuiet("fetch", url,
+		fmt.Sprintf("+refs/heads/%s:%s", target, backportBaseRef),
+		fmt.Sprintf("+refs/heads/%s:%s", mr.TargetBranch, backportTargetRef),
+		fmt.Sprintf("+refs/merge-requests/%d/head:%s", mr.IID, backportMRRef))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch %s: %s", target, err)
+	}
+	defer func() {
+		for _, ref := range []string{backportBaseRef, backportTargetRef, backportMRRef} {
+			gitQuiet("update-ref", "-d", ref)
+		}
+	}()
+
+	dir, err := ioutil.TempDir("", "lab-backport-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = gitQuiet("worktree", "add", "--detach", dir, backportBaseRef)
+	if err != nil {
+		return nil, fmt.Errorf("could not create worktree: %s", err)
+	}
+	defer gitQuiet("worktree", "remove", "--force", dir)
+
+	_, err = gitQuiet(append([]string{"-C", dir, "cherry-pick", "-x"}, commits...)...)
+	if err != nil {
+		out, diffErr := gitQuiet("-C", dir, "diff", "--name-only", "--diff-filter=U")
+		gitQuiet("-C", dir, "cherry-pick", "--abort")
+		conflicts := strings.Fields(string(out))
+		if diffErr != nil || len(conflicts) == 0 {
+			return nil, fmt.Errorf("could not cherry-pick onto %s: %s", target, err)
+		}
+		return conflicts, nil
+	}
+
+	_, err = gitQuiet("-C", dir, "push", url, "HEAD:refs/heads/"+branch)
+	if err != nil {
+		return nil, fmt.Errorf("could not push %s: %s", branch, err)
+	}
+	return nil, nil
+}
+
+// mrBackportCreate opens the backport MR and links it from the original one
+func mrBackportCreate(rn string, mr *gitlab.MergeRequest, commits []string, target, branch string, labels gitlab.LabelOptions, draft bool) (string, error) {
+	title := fmt.Sprintf("[%s] %s", target, mr.Title)
+	if draft {
+		title = "Draft: " + title
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "Backport of !%d to `%s`.\n\nCherry-picked commits:\n", mr.IID, target)
+	for _, sha := range commits {
+		fmt.Fprintf(&description, "\n- %s", sha)
+	}
+	body := description.String()
+
+	removeSourceBranch := true
+	mrURL, err := lab.MRCreate(rn, &gitlab.CreateMergeRequestOptions{
+		Title:              &title,
+		Description:        &body,
+		SourceBranch:       &branch,
+		TargetBranch:       &target,
+		Labels:             &labels,
+		RemoveSourceBranch: &removeSourceBranch,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	_, err = lab.MRCreateNote(rn, mr.IID, &gitlab.CreateMergeRequestNoteOptions{
+		Body: gitlab.String(fmt.Sprintf("Backported to `%s` in %s", target, mrURL)),
+	})
+	if err != nil {
+		log.Errorln(err)
+	}
+	return mrURL, nil
+}
+
+func init() {
+	mrBackportCmd.Flags().StringSlice("to", []string{}, "branch to backport the merge request to; can be specified multiple times")
+	mrBackportCmd.Flags().StringSliceP("label", "l", []string{}, "add label <label>; can be specified multiple times for multiple labels")
+	mrBackportCmd.Flags().Bool("draft", false, "mark the backport merge requests as draft")
+	// useHTTP is defined in "project_create.go"
+	mrBackportCmd.Flags().BoolVar(&useHTTP, "http", false, "use HTTP protocol instead of SSH for local cherry-picks")
+	mrCmd.AddCommand(mrBackportCmd)
+	carapace.Gen(mrBackportCmd).FlagCompletion(carapace.ActionMap{
+		"to": action.RemoteBranches(-1),
+		"label": carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
+			project, _, err := parseArgsRemoteAndProject(c.Args)
+			if err != nil {
+				return carapace.ActionMessage(err.Error())
+			}
+			return action.Labels(project).Invoke(c).FilterParts()
+		}),
+	})
+	carapace.Gen(mrBackportCmd).PositionalCompletion(
+		action.Remotes(),
+		action.MergeRequests(mrList),
+	)
+}
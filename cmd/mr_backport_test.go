@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_mrBackportCommitsSquash(t *testing.T) {
+	mr := &gitlab.MergeRequest{
+		BasicMergeRequest: gitlab.BasicMergeRequest{
+			IID:             10,
+			MergeCommitSHA:  "0d9c2e8a4f5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d",
+			SquashCommitSHA: "54fd0af3e5a47d96e5f3f3fc20fa7b1b1c1ac20d",
+		},
+	}
+	commits, err := mrBackportCommits("zaquestion/test", mr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"54fd0af3e5a47d96e5f3f3fc20fa7b1b1c1ac20d"}, commits)
+}
+
+func Test_mrBackportLocal(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "lab")
+	t.Setenv("GIT_AUTHOR_EMAIL", "lab@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "lab")
+	t.Setenv("GIT_COMMITTER_EMAIL", "lab@example.com")
+
+	tmp := t.TempDir()
+	origin := filepath.Join(tmp, "origin.git")
+	repo := filepath.Join(tmp, "repo")
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(file, content string) string {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(repo, file), []byte(content), 0644))
+		git("add", file)
+		git("commit", "-q", "-m", file)
+		return git("rev-parse", "HEAD")
+	}
+
+	require.NoError(t, exec.Command("git", "init", "-q", "--bare", origin).Run())
+	require.NoError(t, exec.Command("git", "init", "-q", repo).Run())
+	base := commit("a", "1\n")
+	fix := commit("b", "fix\n")
+	conflicting := commit("a", "2\n")
+	git("push", "-q", origin, base+":refs/heads/release", "HEAD:refs/heads/main", "HEAD:refs/merge-requests/1/head")
+	git("checkout", "-q", "-b", "release-conflict", base)
+	commit("a", "3\n")
+	git("push", "-q", origin, "HEAD:refs/heads/release-conflict")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(repo))
+	defer os.Chdir(wd)
+
+	mr := &gitlab.MergeRequest{
+		BasicMergeRequest: gitlab.BasicMergeRequest{IID: 1, TargetBranch: "main"},
+	}
+
+	conflicts, err := mrBackportLocal(origin, mr, []string{fix}, "release", "backport-1-to-release")
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	git("fetch", "-q", origin, "backport-1-to-release")
+	assert.Equal(t, "fix", git("show", "FETCH_HEAD:b"))
+	assert.Equal(t, base, git("rev-parse", "FETCH_HEAD^"))
+
+	conflicts, err = mrBackportLocal(origin, mr, []string{conflicting}, "release-conflict", "backport-1-to-release-conflict")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, conflicts)
+
+	// temporary refs and worktrees are cleaned up
+	assert.Empty(t, git("for-each-ref", "refs/lab/"))
+	assert.Len(t, strings.Split(git("worktree", "list"), "\n"), 1)
+}
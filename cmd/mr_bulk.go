@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var mrBulkCmd = &cobra.Command{
+	Use:   "bulk [remote] [search]",
+	Short: "Edit all the merge requests matching the given filters",
+	Long: heredoc.Doc(`
+		Edit all the merge requests selected by the same filters as
+		"lab mr list", and optionally by age with --older-than. Labels and
+		assignees can be added and removed, the milestone set, a note added,
+		and the merge requests closed.
+
+		The selected merge requests and the changes are shown before asking
+		for confirmation, or only shown with --dry-run. The changes are made
+		in parallel and the merge requests that couldn't be changed are
+		reported at the end.`),
+	Example: heredoc.Doc(`
+		lab mr bulk --draft --older-than 90 --close -m "Closing abandoned draft"
+		lab mr bulk upstream -t release-1.0 --add-label backport --dry-run
+		lab mr bulk --author johndoe --assign janedoe --unassign johndoe -y`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, _, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mrLabels, _ = cmd.Flags().GetStringSlice("label")
+		mrMilestone, _ = cmd.Flags().GetString("milestone")
+		mrAuthor, _ = cmd.Flags().GetString("author")
+		mrAssignee, _ = cmd.Flags().GetString("assignee")
+		mrState, _ = cmd.Flags().GetString("state")
+		mrTargetBranch, _ = cmd.Flags().GetString("target-branch")
+		mrDraft, _ = cmd.Flags().GetBool("draft")
+		mrNumRet = "-1"
+		days, err := bulkAge(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		edit, err := bulkEditFromFlags(cmd, rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mrs, err := mrList(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var items []bulkItem
+		for _, mr := range mrs {
+			item := bulkItem{IID: mr.IID, Title: mr.Title, UpdatedAt: mr.UpdatedAt}
+			for _, a := range mr.Assignees {
+				item.Assignees = append(item.Assignees, a.ID)
+			}
+			items = append(items, item)
+		}
+		items = bulkFilterOlderThan(items, days, time.Now())
+
+		runBulk(cmd, items, edit, "merge request", "!", func(item bulkItem) error {
+			opts := &gitlab.UpdateMergeRequestOptions{
+				MilestoneID: edit.milestoneID,
+			}
+			if len(edit.addLabels) > 0 {
+				opts.AddLabels = &edit.addLabels
+			}
+			if len(edit.removeLabels) > 0 {
+				opts.RemoveLabels = &edit.removeLabels
+			}
+			if edit.changesAssignees() {
+				assignees := edit.assignees(item.Assignees)
+				opts.AssigneeIDs = &assignees
+			}
+			if edit.close {
+				opts.StateEvent = gitlab.String("close")
+			}
+
+			if edit.note != "" {
+				_, err := lab.MRCreateNote(rn, item.IID, &gitlab.CreateMergeRequestNoteOptions{
+					Body: &edit.note,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if *opts == (gitlab.UpdateMergeRequestOptions{}) {
+				return nil
+			}
+			_, err := lab.MRUpdate(rn, item.IID, opts)
+			return err
+		})
+	},
+}
+
+func init() {
+	bulkAddFlags(mrBulkCmd)
+	mrBulkCmd.Flags().StringP("target-branch", "t", "", "select by target branch")
+	mrBulkCmd.Flags().Bool("draft", false, "select only the ones marked as draft")
+	mrBulkCmd.Flags().SortFlags = false
+	mrCmd.AddCommand(mrBulkCmd)
+
+	carapace.Gen(mrBulkCmd).FlagCompletion(carapace.ActionMap{
+		"label":         bulkLabelCompletion(),
+		"add-label":     bulkLabelCompletion(),
+		"remove-label":  bulkLabelCompletion(),
+		"milestone":     bulkMilestoneCompletion(),
+		"set-milestone": bulkMilestoneCompletion(),
+		"state":         carapace.ActionValues("all", "opened", "closed", "merged"),
+		"target-branch": action.RemoteBranches(-1),
+	})
+	carapace.Gen(mrBulkCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
 	"github.com/rsteube/carapace"
 	"github.com/spf13/cobra"
 	"github.com/zaquestion/lab/internal/action"
@@ -13,15 +16,20 @@ import (
 
 // mrCheckoutConfig holds configuration values for calls to lab mr checkout
 type mrCheckoutConfig struct {
-	branch string
-	force  bool
-	track  bool
+	branch   string
+	force    bool
+	track    bool
+	worktree string
 }
 
 var (
 	mrCheckoutCfg mrCheckoutConfig
 )
 
+// mrBranchConfigKey is the branch config entry linking a branch checked out
+// by "lab mr checkout" to its merge request, stored as <project>!<MR id>
+const mrBranchConfigKey = "lab-mr"
+
 // listCmd represents the list command
 var checkoutCmd = &cobra.Command{
 	Use:     "checkout [remote] [<MR id or branch>]",
@@ -30,14 +38,21 @@ var checkoutCmd = &cobra.Command{
 	Long: heredoc.Doc(`
 		Checkout an open merge request using the MR's source branch name as
 		local branch name; this behavior can be changed using --branch
-		option.`),
+		option.
+
+		With --worktree, the merge request is checked out in a new worktree
+		instead of the current one, by default next to the main worktree.
+		Worktrees of merged or closed merge requests can be removed with
+		"lab mr worktree prune".`),
 	Args: cobra.RangeArgs(1, 2),
 	Example: heredoc.Doc(`
 		lab mr checkout origin 10
 		lab mr checkout upstream -b a_branch_name
 		lab mr checkout a_remote -f
 		lab mr checkout upstream --https
-		lab mr checkout upstream -t`),
+		lab mr checkout upstream -t
+		lab mr checkout upstream 10 --worktree
+		lab mr checkout upstream 10 --worktree=../mr-10`),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		rn, mrID, err := parseArgsRemoteAndID(args)
@@ -144,6 +159,25 @@ var checkoutCmd = &cobra.Command{
 			}
 		}
 
+		err = git.New("config", "branch."+mrCheckoutCfg.branch+"."+mrBranchConfigKey,
+			fmt.Sprintf("%s!%d", rn, mrID)).Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if mrCheckoutCfg.worktree != "" {
+			path, err := mrWorktreePath(mrCheckoutCfg.worktree, mrID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = mrWorktreeAdd(path, mrCheckoutCfg.branch)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(path)
+			return
+		}
+
 		err = git.New("checkout", mrCheckoutCfg.branch).Run()
 		if err != nil {
 			log.Fatal(err)
@@ -151,12 +185,26 @@ var checkoutCmd = &cobra.Command{
 	},
 }
 
+func parseMRBranchConfig(value string) (string, int, error) {
+	i := strings.LastIndex(value, "!")
+	if i <= 0 {
+		return "", 0, errors.Errorf("invalid merge request reference %q", value)
+	}
+	id, err := strconv.Atoi(value[i+1:])
+	if err != nil {
+		return "", 0, errors.Errorf("invalid merge request reference %q", value)
+	}
+	return value[:i], id, nil
+}
+
 func init() {
 	checkoutCmd.Flags().StringVarP(&mrCheckoutCfg.branch, "branch", "b", "", "checkout merge request with <branch> name")
 	checkoutCmd.Flags().BoolVarP(&mrCheckoutCfg.track, "track", "t", false, "set branch to track remote branch, adds remote if needed")
 	// useHTTP is defined in "project_create.go"
 	checkoutCmd.Flags().BoolVar(&useHTTP, "http", false, "checkout using HTTP protocol instead of SSH")
 	checkoutCmd.Flags().BoolVarP(&mrCheckoutCfg.force, "force", "f", false, "force branch and remote reference override")
+	checkoutCmd.Flags().StringVarP(&mrCheckoutCfg.worktree, "worktree", "w", "", "checkout merge request in a new worktree at the given path")
+	checkoutCmd.Flags().Lookup("worktree").NoOptDefVal = mrWorktreeDefaultPath
 	mrCmd.AddCommand(checkoutCmd)
 	carapace.Gen(checkoutCmd).PositionalCompletion(
 		carapace.ActionCallback(func(c carapace.Context) carapace.Action {
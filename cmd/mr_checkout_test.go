@@ -4,6 +4,7 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -188,3 +189,15 @@ func Test_mrDoubleCheckoutForceRun(t *testing.T) {
 	}
 	require.Contains(t, eLog, "Deleted branch mrtest")
 }
+
+func Test_parseMRBranchConfig(t *testing.T) {
+	project, id, err := parseMRBranchConfig("zaquestion/test!10")
+	require.NoError(t, err)
+	assert.Equal(t, "zaquestion/test", project)
+	assert.Equal(t, 10, id)
+
+	for _, value := range []string{"", "!10", "zaquestion/test", "zaquestion/test!abc"} {
+		_, _, err := parseMRBranchConfig(value)
+		assert.Error(t, err, value)
+	}
+}
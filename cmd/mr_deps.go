@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var mrDepsCmd = &cobra.Command{
+	Use:              "deps",
+	Aliases:          []string{"dependencies"},
+	Short:            "Manage the merge requests blocking a merge request",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var mrDepsListCmd = &cobra.Command{
+	Use:     "list [remote] [<MR id or branch>]",
+	Aliases: []string{"ls"},
+	Short:   "List the merge requests blocking a merge request",
+	Example: heredoc.Doc(`
+		lab mr deps list
+		lab mr deps list origin 10`),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		deps, err := lab.MRListDependencies(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, dep := range deps {
+			fmt.Printf("%s %s %s\n", mrDependencyRef(dep), dep.BlockingMergeRequest.State,
+				dep.BlockingMergeRequest.Title)
+		}
+	},
+}
+
+var mrDepsAddCmd = &cobra.Command{
+	Use:   "add [remote] [<MR id or branch>] <blocking MR>",
+	Short: "Make a merge request depend on another one",
+	Long: heredoc.Doc(`
+		Make a merge request blocked by another one, which can be given as
+		an MR id of the same project or as a full reference to an MR of
+		another project, like group/project!12.`),
+	Example: heredoc.Doc(`
+		lab mr deps add 12
+		lab mr deps add origin 10 12
+		lab mr deps add origin 10 group/project!3`),
+	Args:             cobra.RangeArgs(1, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args[:len(args)-1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		blockingProject, blockingID, err := parseMRReference(args[len(args)-1], rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		blocking, err := lab.MRGet(blockingProject, blockingID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = lab.MRCreateDependency(rn, int(id), blocking.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Merge Request !%d is now blocked by %s\n", id, args[len(args)-1])
+	},
+}
+
+var mrDepsRemoveCmd = &cobra.Command{
+	Use:     "remove [remote] [<MR id or branch>] <blocking MR>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a dependency of a merge request",
+	Example: heredoc.Doc(`
+		lab mr deps remove 12
+		lab mr deps remove origin 10 12
+		lab mr deps remove origin 10 group/project!3`),
+	Args:             cobra.RangeArgs(1, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args[:len(args)-1])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		blockingProject, blockingID, err := parseMRReference(args[len(args)-1], rn)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		blocking, err := lab.MRGet(blockingProject, blockingID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		deps, err := lab.MRListDependencies(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, dep := range deps {
+			if dep.BlockingMergeRequest.ID != blocking.ID {
+				continue
+			}
+			err = lab.MRDeleteDependency(rn, int(id), dep.ID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Merge Request !%d is no longer blocked by %s\n", id, args[len(args)-1])
+			return
+		}
+		log.Fatalf("Merge Request !%d is not blocked by %s", id, args[len(args)-1])
+	},
+}
+
+// parseMRReference parses a reference to a MR, either an id of a MR in
+// project, with or without the leading "!", or a full group/project!id
+// reference
+func parseMRReference(ref, project string) (string, int, error) {
+	if i := strings.LastIndex(ref, "!"); i > 0 {
+		project, ref = ref[:i], ref[i:]
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(ref, "!"))
+	if err != nil || id <= 0 {
+		return "", 0, errors.Errorf("invalid merge request reference %s", ref)
+	}
+	return project, id, nil
+}
+
+// mrDependencyRef returns the shortest reference to the blocking MR, as
+// seen from the blocked one
+func mrDependencyRef(dep gitlab.MergeRequestDependency) string {
+	mr := dep.BlockingMergeRequest
+	if mr.ProjectID != dep.ProjectID && mr.References != nil {
+		return mr.References.Full
+	}
+	return fmt.Sprintf("!%d", mr.Iid)
+}
+
+// mrOpenDependencies returns the references of the dependencies that were
+// not merged yet, with their state
+func mrOpenDependencies(deps []gitlab.MergeRequestDependency) []string {
+	var open []string
+	for _, dep := range deps {
+		if dep.BlockingMergeRequest.State == "merged" {
+			continue
+		}
+		open = append(open, fmt.Sprintf("%s (%s)", mrDependencyRef(dep), dep.BlockingMergeRequest.State))
+	}
+	return open
+}
+
+func init() {
+	mrDepsCmd.AddCommand(mrDepsListCmd)
+	mrDepsCmd.AddCommand(mrDepsAddCmd)
+	mrDepsCmd.AddCommand(mrDepsRemoveCmd)
+	mrCmd.AddCommand(mrDepsCmd)
+
+	for _, cmd := range []*cobra.Command{mrDepsListCmd, mrDepsAddCmd, mrDepsRemoveCmd} {
+		carapace.Gen(cmd).PositionalCompletion(
+			action.Remotes(),
+			action.MergeRequests(mrList),
+		)
+	}
+}
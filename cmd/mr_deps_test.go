@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_parseMRReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		project string
+		id      int
+	}{
+		{"12", "zaquestion/test", 12},
+		{"!12", "zaquestion/test", 12},
+		{"group/project!3", "group/project", 3},
+	}
+	for _, test := range tests {
+		project, id, err := parseMRReference(test.ref, "zaquestion/test")
+		require.NoError(t, err)
+		assert.Equal(t, test.project, project)
+		assert.Equal(t, test.id, id)
+	}
+
+	for _, ref := range []string{"", "!", "abc", "group/project!", "-1"} {
+		_, _, err := parseMRReference(ref, "zaquestion/test")
+		assert.Error(t, err, ref)
+	}
+}
+
+func Test_mrOpenDependencies(t *testing.T) {
+	deps := []gitlab.MergeRequestDependency{
+		{
+			ProjectID:            1,
+			BlockingMergeRequest: gitlab.BlockingMergeRequest{Iid: 2, ProjectID: 1, State: "merged"},
+		},
+		{
+			ProjectID:            1,
+			BlockingMergeRequest: gitlab.BlockingMergeRequest{Iid: 3, ProjectID: 1, State: "opened"},
+		},
+		{
+			ProjectID: 1,
+			BlockingMergeRequest: gitlab.BlockingMergeRequest{
+				Iid:        4,
+				ProjectID:  5,
+				State:      "closed",
+				References: &gitlab.IssueReferences{Full: "group/project!4"},
+			},
+		},
+	}
+	assert.Equal(t, []string{"!3 (opened)", "group/project!4 (closed)"}, mrOpenDependencies(deps))
+	assert.Empty(t, mrOpenDependencies(nil))
+}
@@ -43,6 +43,7 @@ var (
 	mrSortedBy     string
 	mrReviewer     string
 	mrReviewerID   *gitlab.ReviewerIDValue
+	mrGroup        string
 )
 
 func truncateText(s string, length int) (string) {
@@ -212,7 +213,9 @@ var listCmd = &cobra.Command{
 		lab mr list --no-conflicts
 		lab mr list -x 'test MR'
 		lab mr list -r johndoe
-		lab mr list --show-status`),
+		lab mr list --show-status
+		lab mr list --group --reviewer johndoe
+		lab mr list --group=my-group/sub-group -l bug`),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		rn, err := git.PathWithNamespace(defaultRemote)
@@ -229,6 +232,16 @@ var listCmd = &cobra.Command{
 		defer pager.Close()
 
 		showstatus, _ := cmd.Flags().GetBool("show-status")
+		if showstatus && mrGroup != "" {
+			log.Fatal("--show-status can't be used with --group")
+		}
+
+		if mrGroup != "" {
+			for _, mr := range mrs {
+				fmt.Printf("%s %s\n", mrFullReference(mr), mr.Title)
+			}
+			return
+		}
 
 		if !showstatus {
 			for _, mr := range mrs {
@@ -339,9 +352,14 @@ func mrList(args []string) ([]*gitlab.BasicMergeRequest, error) {
 		return nil, err
 	}
 
-	labels, err := mapLabelsAsLabelOptions(rn, mrLabels)
-	if err != nil {
-		return nil, err
+	// the labels and milestones of a group can't be matched against the
+	// ones of the project, so they are used as given
+	labels := gitlab.LabelOptions(mrLabels)
+	if mrGroup == "" {
+		labels, err = mapLabelsAsLabelOptions(rn, mrLabels)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	num, err := strconv.Atoi(mrNumRet)
@@ -392,7 +410,7 @@ func mrList(args []string) ([]*gitlab.BasicMergeRequest, error) {
 		mrMilestone = "Any"
 	} else if strings.ToLower(mrMilestone) == "none" {
 		mrMilestone = "None"
-	} else if mrMilestone != "" {
+	} else if mrMilestone != "" && mrGroup == "" {
 		milestone, err := lab.MilestoneGet(rn, mrMilestone)
 		if err != nil {
 			log.Fatal(err)
@@ -450,7 +468,31 @@ func mrList(args []string) ([]*gitlab.BasicMergeRequest, error) {
 		opts.Search = &search
 	}
 
-	mrs, err := lab.MRList(rn, opts, num)
+	var mrs []*gitlab.BasicMergeRequest
+	if mrGroup != "" {
+		var group *gitlab.Group
+		group, err = resolveGroup(mrGroup, rn)
+		if err != nil {
+			return nil, err
+		}
+		mrs, err = lab.GroupMRList(group.ID, gitlab.ListGroupMergeRequestsOptions{
+			Labels:                 opts.Labels,
+			State:                  opts.State,
+			TargetBranch:           opts.TargetBranch,
+			Milestone:              opts.Milestone,
+			OrderBy:                opts.OrderBy,
+			Sort:                   opts.Sort,
+			AuthorID:               opts.AuthorID,
+			ApprovedByIDs:          opts.ApprovedByIDs,
+			AssigneeID:             opts.AssigneeID,
+			WithMergeStatusRecheck: opts.WithMergeStatusRecheck,
+			ReviewerID:             opts.ReviewerID,
+			WIP:                    opts.WIP,
+			Search:                 opts.Search,
+		}, num)
+	} else {
+		mrs, err = lab.MRList(rn, opts, num)
+	}
 	if err != nil {
 		return mrs, err
 	}
@@ -506,6 +548,10 @@ func init() {
 		&mrReviewer, "reviewer", "", "list only MRs with reviewer set to $username/any/none")
 	listCmd.Flags().BoolP("show-status", "", false, "show CI and MR status (slow on projects with large number of MRs)")
 	listCmd.Flags().BoolP("no-unicode", "", false, "Do not use unicode in output")
+	listCmd.Flags().StringVar(
+		&mrGroup, "group", "",
+		"list the MRs of the given group, or of the group of the project when no group is given")
+	listCmd.Flags().Lookup("group").NoOptDefVal = groupCurrent
 
 
 	mrCmd.AddCommand(listCmd)
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/rsteube/carapace"
@@ -11,7 +12,10 @@ import (
 	lab "github.com/zaquestion/lab/internal/gitlab"
 )
 
-var mergeImmediate bool
+var (
+	mergeImmediate bool
+	mergeStrict    bool
+)
 
 var mrMergeCmd = &cobra.Command{
 	Use:   "merge [remote] [<MR id or branch>]",
@@ -20,10 +24,15 @@ var mrMergeCmd = &cobra.Command{
 		Merges an open merge request. If the pipeline in the project is
 		enabled and is still running for that specific MR, by default,
 		this command will sets the merge to only happen when the pipeline
-		succeeds.`),
+		succeeds.
+
+		A warning is shown when the merge request is blocked by merge
+		requests that were not merged yet; with --strict the merge is
+		refused instead.`),
 	Example: heredoc.Doc(`
 		lab mr merge origin 10
-		lab mr merge upstream 11 -i`),
+		lab mr merge upstream 11 -i
+		lab mr merge upstream 11 --strict`),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		rn, id, err := parseArgsWithGitBranchMR(args)
@@ -31,6 +40,23 @@ var mrMergeCmd = &cobra.Command{
 			log.Fatal(err)
 		}
 
+		// MR dependencies are only available in GitLab Premium, --strict
+		// can't be honored when they can't be checked
+		deps, err := lab.MRListDependencies(rn, int(id))
+		if err != nil {
+			if mergeStrict {
+				log.Fatalf("could not check the dependencies of Merge Request !%d: %s", id, err)
+			}
+			log.Debugln(err)
+		}
+		if blocking := mrOpenDependencies(deps); len(blocking) > 0 {
+			msg := fmt.Sprintf("Merge Request !%d is blocked by %s", id, strings.Join(blocking, ", "))
+			if mergeStrict {
+				log.Fatal(msg)
+			}
+			log.Warnln(msg)
+		}
+
 		opts := gitlab.AcceptMergeRequestOptions{
 			MergeWhenPipelineSucceeds: gitlab.Bool(!mergeImmediate),
 		}
@@ -45,6 +71,7 @@ var mrMergeCmd = &cobra.Command{
 
 func init() {
 	mrMergeCmd.Flags().BoolVarP(&mergeImmediate, "immediate", "i", false, "merge immediately, regardless pipeline results")
+	mrMergeCmd.Flags().BoolVar(&mergeStrict, "strict", false, "refuse to merge while dependencies are not merged")
 	mrCmd.AddCommand(mrMergeCmd)
 	carapace.Gen(mrMergeCmd).PositionalCompletion(
 		action.Remotes(),
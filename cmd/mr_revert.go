@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// mrRevertTmpl is the default description of revert merge requests, which
+// can be replaced by a .gitlab/merge_request_templates/revert.md file
+var mrRevertTmpl = heredoc.Doc(`
+	This reverts merge request !{{.IID}}, "{{.Title}}".
+
+	Reverted commits:
+	{{range .Commits}}
+	- {{.}}{{end}}{{if .Reason}}
+
+	{{.Reason}}{{end}}`)
+
+// mrRevertData is the data available to the revert description template
+type mrRevertData struct {
+	IID     int
+	Title   string
+	URL     string
+	Commits []string
+	Reason  string
+}
+
+var mrRevertCmd = &cobra.Command{
+	Use:   "revert [remote] [<MR id or branch>]",
+	Short: "Open a merge request reverting a merged merge request",
+	Long: heredoc.Doc(`
+		Revert a merged merge request by creating a new branch from its
+		target branch, reverting the merge commit, or the squash commit, on
+		it and opening a new merge request referencing the original one.
+		Merge requests merged with fast-forward have each of their commits
+		reverted instead.
+
+		The description can be customized with a Go template stored in
+		.gitlab/merge_request_templates/revert.md, which has access to the
+		fields .IID, .Title, .URL, .Commits and .Reason.`),
+	Example: heredoc.Doc(`
+		lab mr revert 10
+		lab mr revert upstream 10 -m "Broke the nightly build"
+		lab mr revert upstream 10 --copy-labels --copy-reviewers
+		lab mr revert upstream 10 -b revert-feature -l regression`),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		branch, err := cmd.Flags().GetString("branch")
+		if err != nil {
+			log.Fatal(err)
+		}
+		msgs, err := cmd.Flags().GetStringArray("message")
+		if err != nil {
+			log.Fatal(err)
+		}
+		labelTerms, err := cmd.Flags().GetStringSlice("label")
+		if err != nil {
+			log.Fatal(err)
+		}
+		reviewers, err := cmd.Flags().GetStringSlice("reviewer")
+		if err != nil {
+			log.Fatal(err)
+		}
+		copyLabels, err := cmd.Flags().GetBool("copy-labels")
+		if err != nil {
+			log.Fatal(err)
+		}
+		copyReviewers, err := cmd.Flags().GetBool("copy-reviewers")
+		if err != nil {
+			log.Fatal(err)
+		}
+		draft, err := cmd.Flags().GetBool("draft")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mr, err := lab.MRGet(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if mr.State != "merged" {
+			log.Fatalf("Merge Request !%d is not merged", id)
+		}
+
+		commits, err := mrRevertCommits(rn, mr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if branch == "" {
+			branch = fmt.Sprintf("revert-%s", commits[0][:8])
+		}
+		_, err = lab.BranchCreate(rn, branch, mr.TargetBranch)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, sha := range commits {
+			_, err = lab.CommitRevert(rn, sha, branch)
+			if err != nil {
+				// don't leave a half reverted branch behind
+				if err := lab.BranchDelete(rn, branch); err != nil {
+					log.Errorln(err)
+				}
+				log.Fatalf("could not revert %s: %s", sha, err)
+			}
+		}
+
+		tmpl := lab.LoadGitLabTmpl(lab.TmplMRRevert)
+		if tmpl == "" {
+			tmpl = mrRevertTmpl
+		}
+		description, err := mrRevertDescription(tmpl, mrRevertData{
+			IID:     mr.IID,
+			Title:   mr.Title,
+			URL:     mr.WebURL,
+			Commits: commits,
+			Reason:  strings.Join(msgs, "\n\n"),
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		title := fmt.Sprintf("Revert \"%s\"", mr.Title)
+		if draft {
+			title = "Draft: " + title
+		}
+
+		if copyLabels {
+			labelTerms = union(mr.Labels, labelTerms)
+		}
+		labels, err := mapLabelsAsLabelOptions(rn, labelTerms)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if copyReviewers {
+			reviewers = union(mrGetCurrentReviewers(mr), reviewers)
+		}
+		reviewerIDs := getUserIDs(reviewers)
+
+		removeSourceBranch := true
+		mrURL, err := lab.MRCreate(rn, &gitlab.CreateMergeRequestOptions{
+			Title:              &title,
+			Description:        &description,
+			SourceBranch:       &branch,
+			TargetBranch:       &mr.TargetBranch,
+			Labels:             &labels,
+			ReviewerIDs:        &reviewerIDs,
+			RemoveSourceBranch: &removeSourceBranch,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// link the original MR back to its revert
+		_, err = lab.MRCreateNote(rn, int(id), &gitlab.CreateMergeRequestNoteOptions{
+			Body: gitlab.String(fmt.Sprintf("Reverted in %s", mrURL)),
+		})
+		if err != nil {
+			log.Errorln(err)
+		}
+
+		fmt.Println(mrURL)
+	},
+}
+
+// mrRevertCommits returns the commits to revert, in order, to undo a merged
+// MR: its merge or squash commit when there's one, or all of its commits,
+// newest first, when it was fast-forwarded
+func mrRevertCommits(rn string, mr *gitlab.MergeRequest) ([]string, error) {
+	if mr.MergeCommitSHA != "" {
+		return []string{mr.MergeCommitSHA}, nil
+	}
+	if mr.SquashCommitSHA != "" {
+		return []string{mr.SquashCommitSHA}, nil
+	}
+
+	mrCommits, err := lab.MRListCommits(rn, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mrCommits) == 0 {
+		return nil, fmt.Errorf("no commits found for Merge Request !%d", mr.IID)
+	}
+
+	commits := make([]string, len(mrCommits))
+	for i, c := range mrCommits {
+		commits[i] = c.ID
+	}
+	return commits, nil
+}
+
+func mrRevertDescription(tmpl string, data mrRevertData) (string, error) {
+	t, err := template.New("tmpl").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+func init() {
+	mrRevertCmd.Flags().StringP("branch", "b", "", "name of the branch holding the revert (default \"revert-<sha>\")")
+	mrRevertCmd.Flags().StringArrayP("message", "m", []string{}, "reason for the revert; multiple -m are concatenated as separate paragraphs")
+	mrRevertCmd.Flags().StringSliceP("label", "l", []string{}, "add label <label>; can be specified multiple times for multiple labels")
+	mrRevertCmd.Flags().StringSliceP("reviewer", "r", []string{}, "set reviewer by username; can be specified multiple times for multiple reviewers")
+	mrRevertCmd.Flags().Bool("copy-labels", false, "copy the labels of the reverted merge request")
+	mrRevertCmd.Flags().Bool("copy-reviewers", false, "copy the reviewers of the reverted merge request")
+	mrRevertCmd.Flags().Bool("draft", false, "mark the revert merge request as draft")
+	mrCmd.AddCommand(mrRevertCmd)
+	carapace.Gen(mrRevertCmd).PositionalCompletion(
+		action.Remotes(),
+		action.MergeRequests(mrList),
+	)
+}
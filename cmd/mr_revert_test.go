@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mrRevertDescription(t *testing.T) {
+	data := mrRevertData{
+		IID:     10,
+		Title:   "Add feature",
+		Commits: []string{"54fd0af3e5a47d96e5f3f3fc20fa7b1b1c1ac20d"},
+	}
+
+	description, err := mrRevertDescription(mrRevertTmpl, data)
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		This reverts merge request !10, "Add feature".
+
+		Reverted commits:
+
+		- 54fd0af3e5a47d96e5f3f3fc20fa7b1b1c1ac20d`), description)
+
+	data.Reason = "Broke the build"
+	description, err = mrRevertDescription(mrRevertTmpl, data)
+	require.NoError(t, err)
+	assert.Contains(t, description, "54fd0af3e5a47d96e5f3f3fc20fa7b1b1c1ac20d\n\nBroke the build")
+
+	description, err = mrRevertDescription("Revert {{.URL}}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Revert", description)
+
+	_, err = mrRevertDescription("{{.Unknown}}", data)
+	assert.Error(t, err)
+}
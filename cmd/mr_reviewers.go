@@ -0,0 +1,439 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// GitLab looks for the CODEOWNERS file in these locations, using the first
+// one found.
+var codeOwnersFiles = []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+const (
+	// weight of each changed path a user is code owner of
+	codeOwnerWeight = 3.0
+	// commits lose half of their weight every historyHalfLife
+	historyHalfLife = 30 * 24 * time.Hour
+)
+
+var mrReviewersCmd = &cobra.Command{
+	Use:              "reviewers",
+	Short:            "Reviewer operations on merge requests",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var mrReviewersSuggestCmd = &cobra.Command{
+	Use:   "suggest [remote] [<MR id or branch>]",
+	Short: "Suggest reviewers for a merge request",
+	Long: heredoc.Doc(`
+		Suggest reviewers for a merge request based on the CODEOWNERS file
+		of the target project and on who recently authored commits touching
+		the paths changed by the merge request, according to the local git
+		history of the target branch. The merge request author is never
+		suggested.`),
+	Example: heredoc.Doc(`
+		lab mr reviewers suggest
+		lab mr reviewers suggest origin 10
+		lab mr reviewers suggest upstream 10 -n 5 --since "3 months"
+		lab mr reviewers suggest upstream my_branch --apply`),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		numRet, err := cmd.Flags().GetString("number")
+		if err != nil {
+			log.Fatal(err)
+		}
+		num, err := strconv.Atoi(numRet)
+		if err != nil {
+			log.Fatalf("invalid number of reviewers: %s", numRet)
+		}
+
+		since, err := cmd.Flags().GetString("since")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		apply, err := cmd.Flags().GetBool("apply")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mr, err := lab.MRGet(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		diffs, err := lab.MRListDiffs(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+		paths := mrChangedPaths(diffs)
+		if len(paths) == 0 {
+			log.Fatalf("Merge Request !%d has no changes", id)
+		}
+
+		candidates := make(map[string]*reviewerCandidate)
+
+		rules, err := loadCodeOwners(rn, mr.TargetBranch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, path := range paths {
+			for _, owner := range codeOwnersForPath(rules, path) {
+				username := codeOwnerUsername(owner)
+				if username == "" {
+					continue
+				}
+				getReviewerCandidate(candidates, username).addOwnedPath()
+			}
+		}
+
+		ref := targetBranchRef(rn, mr.TargetBranch)
+		authors, err := git.PathAuthors(ref, since, paths)
+		if err != nil {
+			log.Fatal(err)
+		}
+		usernames := make(map[string]string)
+		now := time.Now()
+		for _, author := range authors {
+			username, ok := usernames[author.Email]
+			if !ok {
+				username = commitAuthorUsername(author.Email)
+				usernames[author.Email] = username
+			}
+			if username == "" {
+				log.Debugf("no GitLab user found for %s <%s>", author.Name, author.Email)
+				continue
+			}
+			getReviewerCandidate(candidates, username).addCommit(now.Sub(author.Date))
+		}
+
+		suggestions := rankReviewers(candidates, []string{mr.Author.Username})
+
+		var suggested []string
+		for _, s := range suggestions {
+			if len(suggested) == num {
+				break
+			}
+			// code owners may also be groups, which can't be reviewers
+			if getUserID(s.Username) == nil {
+				continue
+			}
+			suggested = append(suggested, s.Username)
+			fmt.Printf("%s (%s)\n", s.Username, s.reason())
+		}
+
+		if len(suggested) == 0 {
+			fmt.Printf("No reviewers found for Merge Request !%d\n", id)
+			return
+		}
+
+		if !apply {
+			return
+		}
+
+		reviewerIDs, changed, err := getUpdateUsers(mrGetCurrentReviewers(mr), suggested, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !changed {
+			fmt.Println("Suggested reviewers are already set")
+			return
+		}
+		mrURL, err := lab.MRUpdate(rn, int(id), &gitlab.UpdateMergeRequestOptions{
+			ReviewerIDs: &reviewerIDs,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(mrURL)
+	},
+}
+
+// reviewerCandidate accumulates the reasons a user is suggested as reviewer
+type reviewerCandidate struct {
+	Username   string
+	OwnedPaths int
+	Commits    int
+	Score      float64
+}
+
+func (c *reviewerCandidate) addOwnedPath() {
+	c.OwnedPaths++
+	c.Score += codeOwnerWeight
+}
+
+// addCommit adds a commit authored age ago; older commits weigh less
+func (c *reviewerCandidate) addCommit(age time.Duration) {
+	c.Commits++
+	c.Score += math.Pow(0.5, float64(age)/float64(historyHalfLife))
+}
+
+func (c *reviewerCandidate) reason() string {
+	var reasons []string
+	if c.OwnedPaths > 0 {
+		reasons = append(reasons, fmt.Sprintf("code owner of %d changed files", c.OwnedPaths))
+	}
+	if c.Commits > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d recent commits", c.Commits))
+	}
+	return strings.Join(reasons, ", ")
+}
+
+func getReviewerCandidate(candidates map[string]*reviewerCandidate, username string) *reviewerCandidate {
+	key := strings.ToLower(username)
+	c, ok := candidates[key]
+	if !ok {
+		c = &reviewerCandidate{Username: username}
+		candidates[key] = c
+	}
+	return c
+}
+
+// rankReviewers sorts the candidates by score, removing the excluded users
+func rankReviewers(candidates map[string]*reviewerCandidate, exclude []string) []*reviewerCandidate {
+	excluded := make(map[string]bool)
+	for _, e := range exclude {
+		excluded[strings.ToLower(e)] = true
+	}
+
+	var ranked []*reviewerCandidate
+	for key, c := range candidates {
+		if excluded[key] {
+			continue
+		}
+		ranked = append(ranked, c)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Username < ranked[j].Username
+	})
+	return ranked
+}
+
+// mrChangedPaths returns the unique list of paths touched by the MR diffs,
+// including the original path of renamed files
+func mrChangedPaths(diffs []*gitlab.MergeRequestDiff) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, d := range diffs {
+		for _, p := range []string{d.OldPath, d.NewPath} {
+			if p == "" || seen[p] {
+				continue
+			}
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// targetBranchRef returns the local remote tracking ref of the project's
+// branch, falling back to HEAD when there's no such ref
+func targetBranchRef(project, branch string) string {
+	remotes, err := git.Remotes()
+	if err != nil {
+		return "HEAD"
+	}
+	for _, remote := range remotes {
+		path, err := git.PathWithNamespace(remote)
+		if err != nil || path != project {
+			continue
+		}
+		ref := remote + "/" + branch
+		if _, err := git.RevParse("--verify", "--quiet", ref); err == nil {
+			return ref
+		}
+	}
+	return "HEAD"
+}
+
+var noreplyEmailRegexp = regexp.MustCompile(`^(?:\d+-)?([^@]+)@users\.noreply\.`)
+
+// commitAuthorUsername maps a commit author email to a GitLab username
+func commitAuthorUsername(email string) string {
+	if m := noreplyEmailRegexp.FindStringSubmatch(email); m != nil {
+		return m[1]
+	}
+	username, err := lab.UsernameFromEmail(email)
+	if err != nil {
+		log.Debugln(err)
+		return ""
+	}
+	return username
+}
+
+// codeOwnerUsername maps a CODEOWNERS owner entry to a GitLab username.
+// Subgroups are ignored since they can't be a merge request reviewer.
+func codeOwnerUsername(owner string) string {
+	if strings.HasPrefix(owner, "@") {
+		owner = strings.TrimPrefix(owner, "@")
+		if strings.Contains(owner, "/") {
+			return ""
+		}
+		return owner
+	}
+	if strings.Contains(owner, "@") {
+		return commitAuthorUsername(owner)
+	}
+	return ""
+}
+
+// codeOwnersRule is a single pattern entry of a CODEOWNERS file
+type codeOwnersRule struct {
+	section string
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// loadCodeOwners fetches and parses the CODEOWNERS file of a project at the
+// given ref. No rules are returned if the project has no such file.
+func loadCodeOwners(project, ref string) ([]codeOwnersRule, error) {
+	for _, file := range codeOwnersFiles {
+		content, err := lab.GetRawFile(project, file, ref)
+		if err == lab.ErrFileNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseCodeOwners(string(content)), nil
+	}
+	return nil, nil
+}
+
+var codeOwnersSectionRegexp = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// parseCodeOwners parses the content of a CODEOWNERS file. Entries without
+// owners inherit the default owners of their section.
+func parseCodeOwners(content string) []codeOwnersRule {
+	var (
+		rules         []codeOwnersRule
+		section       string
+		defaultOwners []string
+	)
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := codeOwnersSectionRegexp.FindStringSubmatch(line); m != nil {
+			section = strings.ToLower(m[1])
+			defaultOwners = strings.Fields(m[2])
+			continue
+		}
+
+		// spaces in paths are escaped with a backslash
+		fields := strings.Fields(strings.Replace(line, `\ `, "\x00", -1))
+		path := strings.Replace(fields[0], "\x00", " ", -1)
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = defaultOwners
+		}
+
+		rules = append(rules, codeOwnersRule{
+			section: section,
+			pattern: codeOwnersPattern(path),
+			owners:  owners,
+		})
+	}
+	return rules
+}
+
+// codeOwnersPattern converts a CODEOWNERS path pattern into a regexp.
+// Patterns not starting with a slash match at any depth and patterns
+// matching a directory match everything inside it.
+func codeOwnersPattern(path string) *regexp.Regexp {
+	var re strings.Builder
+	re.WriteString("^")
+	if strings.HasPrefix(path, "/") {
+		path = strings.TrimPrefix(path, "/")
+	} else {
+		re.WriteString("(?:.*/)?")
+	}
+	path = strings.TrimSuffix(path, "/")
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '*':
+			if i+1 < len(path) && path[i+1] == '*' {
+				i++
+				if i+1 < len(path) && path[i+1] == '/' {
+					i++
+					re.WriteString("(?:.*/)?")
+				} else {
+					re.WriteString(".*")
+				}
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("(?:/.*)?$")
+	return regexp.MustCompile(re.String())
+}
+
+// codeOwnersForPath returns the owners of a path. As in GitLab, the last
+// matching entry of each section wins and the owners of all sections are
+// combined.
+func codeOwnersForPath(rules []codeOwnersRule, path string) []string {
+	var sections []string
+	bySection := make(map[string][]string)
+	for _, rule := range rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if _, ok := bySection[rule.section]; !ok {
+			sections = append(sections, rule.section)
+		}
+		bySection[rule.section] = rule.owners
+	}
+
+	var owners []string
+	for _, section := range sections {
+		owners = union(bySection[section], owners)
+	}
+	return owners
+}
+
+func init() {
+	mrReviewersSuggestCmd.Flags().StringP("number", "n", "3", "number of reviewers to suggest")
+	mrReviewersSuggestCmd.Flags().String("since", "6 months", "only consider commits more recent than the given date")
+	mrReviewersSuggestCmd.Flags().Bool("apply", false, "add the suggested reviewers to the merge request")
+
+	mrCmd.AddCommand(mrReviewersCmd)
+	mrReviewersCmd.AddCommand(mrReviewersSuggestCmd)
+	carapace.Gen(mrReviewersSuggestCmd).PositionalCompletion(
+		action.Remotes(),
+		action.MergeRequests(mrList),
+	)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_codeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"README.md", "README.md", true},
+		{"README.md", "docs/README.md", true},
+		{"/README.md", "docs/README.md", false},
+		{"*.go", "cmd/root.go", true},
+		{"*.go", "cmd/root.golden", false},
+		{"/docs/", "docs/guide/index.md", true},
+		{"/docs/", "src/docs/index.md", false},
+		{"docs/", "src/docs/index.md", true},
+		{"/cmd/*.go", "cmd/root.go", true},
+		{"/cmd/*.go", "cmd/sub/root.go", false},
+		{"/cmd/**/*.go", "cmd/sub/root.go", true},
+		{"/cmd/**/*.go", "cmd/root.go", true},
+		{"/internal", "internal/git/git.go", true},
+		{"/internal", "internalize.go", false},
+		{"file?.txt", "file1.txt", true},
+		{"my file.txt", "my file.txt", true},
+	}
+	for _, test := range tests {
+		re := codeOwnersPattern(test.pattern)
+		assert.Equal(t, test.match, re.MatchString(test.path), "%s on %s", test.pattern, test.path)
+	}
+}
+
+func Test_parseCodeOwners(t *testing.T) {
+	rules := parseCodeOwners(`
+# default owners
+* @alice
+
+*.go @bob
+/internal/gitlab/ @carol dave@example.com
+/docs/\ guide/ @erin
+
+[Documentation][2] @frank @@devs
+/docs/
+*.md @grace
+
+^[Optional]
+/cmd/ @heidi
+`)
+	assert.Len(t, rules, 7)
+
+	assert.Equal(t, []string{"@bob"}, codeOwnersForPath(rules, "main.go"))
+	assert.Equal(t, []string{"@carol", "dave@example.com"}, codeOwnersForPath(rules, "internal/gitlab/gitlab.go"))
+	assert.Equal(t, []string{"@alice"}, codeOwnersForPath(rules, "Makefile"))
+	assert.ElementsMatch(t, []string{"@erin", "@frank", "@@devs"}, codeOwnersForPath(rules, "docs/ guide/index.html"))
+	// owners are combined across sections, last match wins within each one
+	assert.ElementsMatch(t, []string{"@alice", "@grace"}, codeOwnersForPath(rules, "README.md"))
+	assert.ElementsMatch(t, []string{"@alice", "@frank", "@@devs"}, codeOwnersForPath(rules, "docs/index.html"))
+	assert.ElementsMatch(t, []string{"@bob", "@heidi"}, codeOwnersForPath(rules, "cmd/mr.go"))
+}
+
+func Test_codeOwnerUsername(t *testing.T) {
+	assert.Equal(t, "alice", codeOwnerUsername("@alice"))
+	assert.Equal(t, "", codeOwnerUsername("@group/subgroup"))
+	assert.Equal(t, "", codeOwnerUsername("garbage"))
+	assert.Equal(t, "bob", commitAuthorUsername("1234-bob@users.noreply.gitlab.com"))
+}
+
+func Test_mrChangedPaths(t *testing.T) {
+	diffs := []*gitlab.MergeRequestDiff{
+		{OldPath: "a.go", NewPath: "a.go"},
+		{OldPath: "b.go", NewPath: "c.go"},
+		{OldPath: "c.go", NewPath: "c.go"},
+	}
+	assert.Equal(t, []string{"a.go", "b.go", "c.go"}, mrChangedPaths(diffs))
+}
+
+func Test_rankReviewers(t *testing.T) {
+	candidates := make(map[string]*reviewerCandidate)
+	getReviewerCandidate(candidates, "alice").addCommit(0)
+	getReviewerCandidate(candidates, "Alice").addCommit(24 * time.Hour)
+	getReviewerCandidate(candidates, "bob").addOwnedPath()
+	getReviewerCandidate(candidates, "carol").addCommit(0)
+	getReviewerCandidate(candidates, "dave").addCommit(365 * 24 * time.Hour)
+	getReviewerCandidate(candidates, "author").addOwnedPath()
+	getReviewerCandidate(candidates, "author").addOwnedPath()
+
+	ranked := rankReviewers(candidates, []string{"Author"})
+	var usernames []string
+	for _, c := range ranked {
+		usernames = append(usernames, c.Username)
+	}
+	assert.Equal(t, []string{"bob", "alice", "carol", "dave"}, usernames)
+	assert.Equal(t, 2, ranked[1].Commits)
+	assert.Equal(t, "code owner of 1 changed files", ranked[0].reason())
+	assert.Equal(t, "2 recent commits", ranked[1].reason())
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -142,7 +143,58 @@ func findLocalRemote(ProjectID int) string {
 	return remote
 }
 
+// mrDetails is what is shown of a merge request besides the merge request
+// itself
+type mrDetails struct {
+	closingIssues []int
+	approvals     *gitlab.MergeRequestApprovals
+	dependencies  []gitlab.MergeRequestDependency
+	subscribed    bool
+}
+
+// getMRDetails fetches the details of a merge request shown by printMR
+func getMRDetails(project string, iid int) (*mrDetails, error) {
+	var (
+		details mrDetails
+		err     error
+	)
+	details.closingIssues, err = lab.ListIssuesClosedOnMerge(project, iid)
+	if err != nil {
+		return nil, err
+	}
+
+	details.approvals, err = lab.GetMRApprovalsConfiguration(project, iid)
+	if err != nil {
+		return nil, err
+	}
+
+	// MR dependencies are only available in GitLab Premium
+	details.dependencies, err = lab.MRListDependencies(project, iid)
+	if err != nil {
+		log.Debugln(err)
+	}
+
+	bmr, err := lab.MRGet(project, iid)
+	if err != nil {
+		return nil, err
+	}
+	details.subscribed = bmr.Subscribed
+	return &details, nil
+}
+
 func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
+	details, err := getMRDetails(project, mrx.IID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = writeMR(os.Stdout, mrx, project, details, renderMarkdown)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeMR writes a merge request and its details as printed by lab mr show
+func writeMR(w io.Writer, mrx *gitlab.MergeRequest, project string, details *mrDetails, renderMarkdown bool) error {
 	assignee := "None"
 	milestone := "None"
 	labels := "None"
@@ -150,6 +202,7 @@ func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
 	approvers := "None"
 	approverGroups := "None"
 	reviewers := "None"
+	blockedBy := "None"
 	subscribed := "No"
 	state := map[string]string{
 		"opened": "Open",
@@ -191,21 +244,12 @@ func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
 	if renderMarkdown {
 		r, err := getTermRenderer(glamour.WithAutoStyle())
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		mr.Description, _ = r.Render(mr.Description)
 	}
 
-	closingIssues, err := lab.ListIssuesClosedOnMerge(project, mr.IID)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	approvalConfig, err := lab.GetMRApprovalsConfiguration(project, mr.IID)
-	if err != nil {
-		log.Fatal(err)
-	}
-
+	approvalConfig := details.approvals
 	for _, approvedby := range approvalConfig.ApprovedBy {
 		_tmpStringArray = append(_tmpStringArray, approvedby.User.Username)
 	}
@@ -244,12 +288,16 @@ func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
 		_tmpStringArray = nil
 	}
 
-	bmr, err := lab.MRGet(project, mr.IID)
-	if err != nil {
-		log.Fatal(err)
+	for _, dep := range details.dependencies {
+		_tmpStringArray = append(_tmpStringArray, fmt.Sprintf("%s (%s)",
+			mrDependencyRef(dep), dep.BlockingMergeRequest.State))
+	}
+	if len(_tmpStringArray) > 0 {
+		blockedBy = strings.Join(_tmpStringArray, ", ")
+		_tmpStringArray = nil
 	}
 
-	if bmr.Subscribed {
+	if details.subscribed {
 		subscribed = "Yes"
 	}
 
@@ -268,7 +316,7 @@ func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
 		ciStatus = color.GreenString(ciStatus)
 	}
 
-	fmt.Printf(
+	_, err := fmt.Fprintf(w,
 		heredoc.Doc(`
 			!%d %s
 			===================================
@@ -286,6 +334,7 @@ func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
 			Milestone: %s
 			Labels: %s
 			Issues Closed by this MR: %s
+			Blocked By: %s
 			Subscribed: %s
 			Created At: %s
 			Updated At: %s
@@ -296,9 +345,10 @@ func printMR(mrx *gitlab.MergeRequest, project string, renderMarkdown bool) {
 		mr.IID, mr.Title, mr.Description, project, mr.SourceBranch,
 		mr.TargetBranch, state, assignee, mr.Author.Username,
 		approvedByUsers, approvers, approverGroups, reviewers, milestone, labels,
-		strings.Trim(strings.Replace(fmt.Sprint(closingIssues), " ", ",", -1), "[]"),
-		subscribed, mr.CreatedAt, mr.UpdatedAt, detailedMergeStatus, ciStatus, mr.WebURL,
+		strings.Trim(strings.Replace(fmt.Sprint(details.closingIssues), " ", ",", -1), "[]"),
+		blockedBy, subscribed, mr.CreatedAt, mr.UpdatedAt, detailedMergeStatus, ciStatus, mr.WebURL,
 	)
+	return err
 }
 
 func init() {
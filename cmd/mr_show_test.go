@@ -40,6 +40,7 @@ Reviewers: None
 Milestone: 1.0
 Labels: documentation
 Issues Closed by this MR: 
+Blocked By: None
 Subscribed: Yes
 Created At: 2017-09-19 03:55:51.674 +0000 UTC
 Updated At: 2023-05-25 01:45:54.027 +0000 UTC
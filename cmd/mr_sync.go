@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitconfig "github.com/tcnksm/go-gitconfig"
+	"github.com/zaquestion/lab/internal/action"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// mrSyncBackupPrefix is where the previous state of a branch is saved
+// before resetting it to a rewritten MR history
+const mrSyncBackupPrefix = "refs/lab/backup/"
+
+var mrSyncCmd = &cobra.Command{
+	Use:   "sync [remote]",
+	Short: "Update the current branch with the latest state of its merge request",
+	Long: heredoc.Doc(`
+		Fetch the head of the merge request of the current branch from its
+		target project, through refs/merge-requests/<id>/head so that the
+		source project needn't be reachable, and update the branch to it.
+
+		The branch is fast-forwarded when possible. When the history of the
+		merge request was rewritten, e.g. by a force-push, the branch is
+		reset to the new head after saving its previous state under
+		refs/lab/backup/<branch>/<timestamp>, unless --ff-only is given.`),
+	Example: heredoc.Doc(`
+		lab mr sync
+		lab mr sync upstream
+		lab mr sync --ff-only`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		ffOnly, err := cmd.Flags().GetBool("ff-only")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		branch, err := git.CurrentBranch()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rn, id, err := mrSyncTarget(args, branch)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mr, err := lab.MRGet(rn, id)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		project, err := lab.GetProject(mr.ProjectID)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// https://docs.gitlab.com/ee/user/project/merge_requests/reviews/#checkout-merge-requests-locally-through-the-head-ref
+		mrRef := fmt.Sprintf("refs/merge-requests/%d/head", mr.IID)
+		err = git.New("fetch", labURLToRepo(project), mrRef).Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		local, err := git.RevParse("HEAD")
+		if err != nil {
+			log.Fatal(err)
+		}
+		remote, err := git.RevParse("FETCH_HEAD")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		switch {
+		case local == remote:
+			fmt.Printf("Branch %s is up to date with !%d\n", branch, id)
+		case isAncestor(local, remote):
+			err = git.New("merge", "--ff-only", "FETCH_HEAD").Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Fast-forwarded %s to !%d (%s)\n", branch, id, remote[:8])
+		case isAncestor(remote, local):
+			fmt.Printf("Branch %s is ahead of !%d, nothing to sync\n", branch, id)
+		default:
+			if ffOnly {
+				log.Fatalf("history of !%d was rewritten, can't fast-forward %s", id, branch)
+			}
+			if worktreeDirty() {
+				log.Fatalf("history of !%d was rewritten and %s has uncommitted changes, commit or stash them first", id, branch)
+			}
+
+			backupRef := fmt.Sprintf("%s%s/%d", mrSyncBackupPrefix, branch, time.Now().Unix())
+			err = git.New("update-ref", backupRef, local).Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+			err = git.New("reset", "--hard", "FETCH_HEAD").Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("History of !%d was rewritten, reset %s to %s\n", id, branch, remote[:8])
+			fmt.Printf("Previous state saved as %s\n", backupRef)
+		}
+	},
+}
+
+// mrSyncTarget finds the MR of the current branch, using the MR recorded by
+// "lab mr checkout" when available
+func mrSyncTarget(args []string, branch string) (string, int, error) {
+	value, err := gitconfig.Local("branch." + branch + "." + mrBranchConfigKey)
+	if err == nil && value != "" && len(args) == 0 {
+		return parseMRBranchConfig(value)
+	}
+
+	rn, id, err := parseArgsWithGitBranchMR(args)
+	if err != nil {
+		return "", 0, err
+	}
+	return rn, int(id), nil
+}
+
+// isAncestor returns whether commit a is an ancestor of commit b
+func isAncestor(a, b string) bool {
+	return git.New("merge-base", "--is-ancestor", a, b).Run() == nil
+}
+
+// worktreeDirty returns whether there are uncommitted changes to tracked
+// files in the current worktree
+func worktreeDirty() bool {
+	cmd := git.New("status", "--porcelain", "--untracked-files=no")
+	cmd.Stdout = nil
+	out, err := cmd.Output()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return strings.TrimSpace(string(out)) != ""
+}
+
+func init() {
+	mrSyncCmd.Flags().Bool("ff-only", false, "refuse to reset the branch when the merge request history was rewritten")
+	// useHTTP is defined in "project_create.go"
+	mrSyncCmd.Flags().BoolVar(&useHTTP, "http", false, "fetch using HTTP protocol instead of SSH")
+	mrCmd.AddCommand(mrSyncCmd)
+	carapace.Gen(mrSyncCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// mrTasks reads and writes the tasks of merge requests
+var mrTasks = tasksTarget{
+	get: func(rn string, id int) (string, *time.Time, error) {
+		mr, err := lab.MRGet(rn, id)
+		if err != nil {
+			return "", nil, err
+		}
+		return mr.Description, mr.UpdatedAt, nil
+	},
+	update: func(rn string, id int, description string) error {
+		_, err := lab.MRUpdate(rn, id, &gitlab.UpdateMergeRequestOptions{
+			Description: &description,
+		})
+		return err
+	},
+}
+
+var mrTasksCmd = &cobra.Command{
+	Use:   "tasks [remote] [<MR id or branch>]",
+	Short: "List the tasks of a merge request",
+	Long: heredoc.Doc(`
+		List the items of the task lists in the description of a merge
+		request, with the index used to check or uncheck them.
+
+		The time the description was last updated is printed too; give it
+		to check or uncheck with --expect-updated-at to refuse the change
+		if the description was edited since.`),
+	Example: heredoc.Doc(`
+		lab mr tasks
+		lab mr tasks 12
+		lab mr tasks check 12 1 3
+		lab mr tasks uncheck upstream 12 2
+		lab mr tasks check 12 1 --expect-updated-at 2024-06-30T12:00:00.123Z`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		description, updatedAt, err := mrTasks.get(rn, int(id))
+		if err != nil {
+			log.Fatal(err)
+		}
+		printTasks(parseTasks(description), updatedAt)
+	},
+}
+
+var mrTasksCheckCmd = &cobra.Command{
+	Use:              "check [remote] <id> <index>...",
+	Short:            "Check tasks of a merge request",
+	Args:             cobra.MinimumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		runMRTasksToggle(cmd, args, true)
+	},
+}
+
+var mrTasksUncheckCmd = &cobra.Command{
+	Use:              "uncheck [remote] <id> <index>...",
+	Short:            "Uncheck tasks of a merge request",
+	Args:             cobra.MinimumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		runMRTasksToggle(cmd, args, false)
+	},
+}
+
+func runMRTasksToggle(cmd *cobra.Command, args []string, checked bool) {
+	rn, id, indices, err := parseTasksArgs(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+	expected, err := tasksExpectedUpdatedAt(cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = toggleTasks(mrTasks, rn, id, indices, checked, expected)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	description, updatedAt, err := mrTasks.get(rn, id)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Merge Request !%d:\n", id)
+	printTasks(parseTasks(description), updatedAt)
+}
+
+func init() {
+	tasksAddFlags(mrTasksCheckCmd)
+	tasksAddFlags(mrTasksUncheckCmd)
+	mrTasksCmd.AddCommand(mrTasksCheckCmd)
+	mrTasksCmd.AddCommand(mrTasksUncheckCmd)
+	mrCmd.AddCommand(mrTasksCmd)
+
+	for _, cmd := range []*cobra.Command{mrTasksCmd, mrTasksCheckCmd, mrTasksUncheckCmd} {
+		carapace.Gen(cmd).PositionalCompletion(
+			action.Remotes(),
+			action.MergeRequests(mrList),
+		)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+)
+
+var mrTimeCmd = &cobra.Command{
+	Use:              "time",
+	Short:            "Time tracking of merge requests",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var mrTimeEstimateCmd = &cobra.Command{
+	Use:   "estimate [remote] [<MR id or branch>] <duration>",
+	Short: "Set the time estimate of a merge request",
+	Example: heredoc.Doc(`
+		lab mr time estimate 1 3h
+		lab mr time estimate origin 1 "1d 4h"`),
+	Args:             cobra.RangeArgs(1, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeEstimateRunFn,
+}
+
+var mrTimeSpendCmd = &cobra.Command{
+	Use:   "spend [remote] [<MR id or branch>] <duration>",
+	Short: "Add time spent on a merge request",
+	Long: heredoc.Doc(`
+		Add time spent on a merge request, using GitLab durations like
+		1h30m or "1d 2h". Negative durations, like -30m, subtract time.`),
+	Example: heredoc.Doc(`
+		lab mr time spend 1 1h30m
+		lab mr time spend origin 1 2h --summary "Investigation"
+		lab mr time spend origin 1 -- -30m
+		lab mr time spend 1 1h --date 2023-05-01`),
+	Args:             cobra.RangeArgs(1, 3),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeSpendRunFn,
+}
+
+var mrTimeResetCmd = &cobra.Command{
+	Use:   "reset [remote] [<MR id or branch>]",
+	Short: "Reset the time estimate and time spent of a merge request",
+	Example: heredoc.Doc(`
+		lab mr time reset 1
+		lab mr time reset origin 1 --spent`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeResetRunFn,
+}
+
+var mrTimeReportCmd = &cobra.Command{
+	Use:   "report [remote]",
+	Short: "Report the time spent per user on merge requests",
+	Long: heredoc.Doc(`
+		Report the time spent per user on the merge requests of a milestone
+		or with the given labels, based on the time tracking notes of each
+		merge request.`),
+	Example: heredoc.Doc(`
+		lab mr time report --milestone 1.0
+		lab mr time report origin --label bug --label backend`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run:              timeReportRunFn,
+}
+
+func init() {
+	mrTimeSpendCmd.Flags().String("summary", "", "summary of the work done")
+	mrTimeSpendCmd.Flags().String("date", "", "date the time was spent at, in the YYYY-MM-DD format")
+	mrTimeResetCmd.Flags().Bool("estimate", false, "only reset the time estimate")
+	mrTimeResetCmd.Flags().Bool("spent", false, "only reset the time spent")
+	mrTimeReportCmd.Flags().String("milestone", "", "report the merge requests of the given milestone")
+	mrTimeReportCmd.Flags().StringSliceP("label", "l", []string{}, "report the merge requests with the given labels")
+
+	mrTimeCmd.AddCommand(mrTimeEstimateCmd)
+	mrTimeCmd.AddCommand(mrTimeSpendCmd)
+	mrTimeCmd.AddCommand(mrTimeResetCmd)
+	mrTimeCmd.AddCommand(mrTimeReportCmd)
+	mrCmd.AddCommand(mrTimeCmd)
+
+	for _, cmd := range []*cobra.Command{mrTimeEstimateCmd, mrTimeSpendCmd, mrTimeResetCmd} {
+		carapace.Gen(cmd).PositionalCompletion(
+			action.Remotes(),
+			action.MergeRequests(mrList),
+		)
+	}
+	carapace.Gen(mrTimeReportCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var mrWatchCmd = &cobra.Command{
+	Use:   "watch [remote] [<MR id or branch>]",
+	Short: "Watch a merge request for new activity",
+	Long: heredoc.Doc(`
+		Check a merge request at regular intervals and print its new notes,
+		approvals, pushes, pipeline transitions and state changes as they
+		appear, until interrupted.
+
+		The command given with --exec is run by the shell for each event,
+		with the LAB_EVENT variable set to its kind and LAB_EVENT_TEXT to
+		its description. With --notify, a desktop notification is shown
+		with notify-send. Both can be limited to some kinds of events with
+		--on.`),
+	Example: heredoc.Doc(`
+		lab mr watch
+		lab mr watch 12 --interval 60
+		lab mr watch 12 --notify --on pipeline,approval
+		lab mr watch upstream 12 --exec 'echo "$LAB_EVENT_TEXT" >> mr.log'`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, id, err := parseArgsWithGitBranchMR(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts, err := watchOptionsFromFlags(cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		snapshot := func() (*watchSnapshot, error) {
+			return mrWatchSnapshot(rn, int(id))
+		}
+		err = watch(cmd.Context(), fmt.Sprintf("%s!%d", rn, id), snapshot, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func mrWatchSnapshot(rn string, id int) (*watchSnapshot, error) {
+	mr, err := lab.MRGet(rn, id)
+	if err != nil {
+		return nil, err
+	}
+	discussions, err := lab.MRListDiscussions(rn, id)
+	if err != nil {
+		return nil, err
+	}
+	approvals, err := lab.GetMRApprovalsConfiguration(rn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &watchSnapshot{
+		State: mr.State,
+		Notes: watchNotes(discussions),
+		SHA:   mr.SHA,
+	}
+	for _, a := range approvals.ApprovedBy {
+		if a.User != nil {
+			s.Approvers = append(s.Approvers, a.User.Username)
+		}
+	}
+	if mr.HeadPipeline != nil {
+		s.PipelineID = mr.HeadPipeline.ID
+		s.PipelineStatus = mr.HeadPipeline.Status
+	}
+	return s, nil
+}
+
+func init() {
+	watchAddFlags(mrWatchCmd)
+	mrCmd.AddCommand(mrWatchCmd)
+
+	carapace.Gen(mrWatchCmd).FlagCompletion(carapace.ActionMap{
+		"on": carapace.ActionValues(watchEventKinds...).UniqueList(","),
+	})
+	carapace.Gen(mrWatchCmd).PositionalCompletion(
+		action.Remotes(),
+		action.MergeRequests(mrList),
+	)
+}
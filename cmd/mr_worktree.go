@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	gitconfig "github.com/tcnksm/go-gitconfig"
+	"github.com/zaquestion/lab/internal/config"
+	"github.com/zaquestion/lab/internal/git"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// mrWorktreeDefaultPath is the value of --worktree when no path is given
+const mrWorktreeDefaultPath = "auto"
+
+// mrWorktree is a worktree holding the checkout of a merge request
+type mrWorktree struct {
+	git.Worktree
+	project string
+	id      int
+}
+
+var mrWorktreeCmd = &cobra.Command{
+	Use:              "worktree",
+	Short:            "Manage worktrees of checked out merge requests",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var mrWorktreeListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List worktrees of checked out merge requests",
+	Example: heredoc.Doc(`
+		lab mr worktree list`),
+	Args:             cobra.NoArgs,
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		worktrees, err := mrWorktrees()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, byte(' '), 0)
+		for _, wt := range worktrees {
+			state := "unknown"
+			mr, err := lab.MRGet(wt.project, wt.id)
+			if err == nil {
+				state = mr.State
+			}
+			fmt.Fprintf(w, "%s!%d\t%s\t%s\t%s\n", wt.project, wt.id, state, wt.Branch, wt.Path)
+		}
+		w.Flush()
+	},
+}
+
+var mrWorktreePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove worktrees of merged or closed merge requests",
+	Long: heredoc.Doc(`
+		Remove the worktrees created by "lab mr checkout --worktree" whose
+		merge request was merged or closed, along with their local branch.
+		Worktrees with uncommitted changes are kept unless --force is given.`),
+	Example: heredoc.Doc(`
+		lab mr worktree prune
+		lab mr worktree prune --dry-run
+		lab mr worktree prune --force`),
+	Args:             cobra.NoArgs,
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal(err)
+		}
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		worktrees, err := mrWorktrees()
+		if err != nil {
+			log.Fatal(err)
+		}
+		current, err := git.WorkingDir()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, wt := range worktrees {
+			mr, err := lab.MRGet(wt.project, wt.id)
+			if err != nil {
+				log.Errorf("%s!%d: %s", wt.project, wt.id, err)
+				continue
+			}
+			if mr.State != "merged" && mr.State != "closed" {
+				continue
+			}
+			if filepath.Clean(wt.Path) == filepath.Clean(current) {
+				fmt.Printf("Skipping %s, it is the current worktree\n", wt.Path)
+				continue
+			}
+
+			fmt.Printf("Removing %s (%s!%d %s)\n", wt.Path, wt.project, wt.id, mr.State)
+			if dryRun {
+				continue
+			}
+
+			gitcmd := []string{"worktree", "remove"}
+			if force {
+				gitcmd = append(gitcmd, "--force")
+			}
+			if err := git.New(append(gitcmd, wt.Path)...).Run(); err != nil {
+				log.Errorf("could not remove worktree %s: %s", wt.Path, err)
+				continue
+			}
+			if err := git.New("branch", "-D", wt.Branch).Run(); err != nil {
+				log.Errorf("could not delete branch %s: %s", wt.Branch, err)
+			}
+		}
+
+		if !dryRun {
+			if err := git.New("worktree", "prune").Run(); err != nil {
+				log.Fatal(err)
+			}
+		}
+	},
+}
+
+// mrWorktrees returns the linked worktrees whose branch was checked out from
+// a merge request, the main worktree never being one of them
+func mrWorktrees() ([]mrWorktree, error) {
+	worktrees, err := git.Worktrees()
+	if err != nil {
+		return nil, err
+	}
+	if len(worktrees) > 0 {
+		worktrees = worktrees[1:]
+	}
+
+	var mrWorktrees []mrWorktree
+	for _, wt := range worktrees {
+		if wt.Branch == "" {
+			continue
+		}
+		value, err := gitconfig.Local("branch." + wt.Branch + "." + mrBranchConfigKey)
+		if err != nil || value == "" {
+			continue
+		}
+		project, id, err := parseMRBranchConfig(value)
+		if err != nil {
+			log.Errorf("branch %s: %s", wt.Branch, err)
+			continue
+		}
+		mrWorktrees = append(mrWorktrees, mrWorktree{Worktree: wt, project: project, id: id})
+	}
+	return mrWorktrees, nil
+}
+
+// mrWorktreePath returns the path of the worktree for the given MR. Unless
+// one is given, worktrees are placed next to the main worktree.
+func mrWorktreePath(path string, mrID int64) (string, error) {
+	if path != "" && path != mrWorktreeDefaultPath {
+		return filepath.Abs(path)
+	}
+
+	worktrees, err := git.Worktrees()
+	if err != nil {
+		return "", err
+	}
+	if len(worktrees) == 0 {
+		return "", errors.New("could not find the main worktree")
+	}
+	main := worktrees[0].Path
+	return filepath.Join(filepath.Dir(main), fmt.Sprintf("%s-mr-%d", filepath.Base(main), mrID)), nil
+}
+
+// mrWorktreeAdd checks out branch in a new worktree at path and carries over
+// the lab worktree config
+func mrWorktreeAdd(path, branch string) error {
+	err := git.New("worktree", "add", path, branch).Run()
+	if err != nil {
+		return err
+	}
+
+	gitDir, err := git.WorktreeDir(path)
+	if err != nil {
+		return err
+	}
+	return config.CopyWorktreeConfig(gitDir)
+}
+
+func init() {
+	mrWorktreePruneCmd.Flags().Bool("dry-run", false, "list the worktrees that would be removed")
+	mrWorktreePruneCmd.Flags().BoolP("force", "f", false, "remove worktrees even if they have uncommitted changes")
+
+	mrWorktreeCmd.AddCommand(mrWorktreeListCmd)
+	mrWorktreeCmd.AddCommand(mrWorktreePruneCmd)
+	mrCmd.AddCommand(mrWorktreeCmd)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:              "release",
+	Short:            "Manage the releases of a project",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// parseReleaseAsset parses an asset link given as "name=url", or as a bare
+// url in which case its last path element is used as name
+func parseReleaseAsset(asset string) (string, string, error) {
+	name, url := "", asset
+	if i := strings.Index(asset, "="); i > 0 && !strings.Contains(asset[:i], "://") {
+		name, url = asset[:i], asset[i+1:]
+	}
+	if !strings.Contains(url, "://") {
+		return "", "", errors.Errorf("invalid asset link %q, use <name>=<url>", asset)
+	}
+	if name == "" {
+		name = url[strings.LastIndex(strings.TrimSuffix(url, "/"), "/")+1:]
+		name = strings.TrimSuffix(name, "/")
+	}
+	return name, url, nil
+}
+
+// releaseAssetLinks returns the links of the asset flags of cmd, uploading
+// the files to the project, or to its generic package registry when a
+// package name is given
+func releaseAssetLinks(cmd *cobra.Command, rn, tag string) ([]*gitlab.ReleaseAssetLinkOptions, error) {
+	assets, err := cmd.Flags().GetStringArray("asset")
+	if err != nil {
+		return nil, err
+	}
+	uploads, err := cmd.Flags().GetStringArray("upload")
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := cmd.Flags().GetString("package")
+	if err != nil {
+		return nil, err
+	}
+
+	var links []*gitlab.ReleaseAssetLinkOptions
+	for _, asset := range assets {
+		name, url, err := parseReleaseAsset(asset)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, &gitlab.ReleaseAssetLinkOptions{
+			Name: gitlab.String(name),
+			URL:  gitlab.String(url),
+		})
+	}
+
+	for _, path := range uploads {
+		var url string
+		linkType := gitlab.OtherLinkType
+		if pkg != "" {
+			url, err = lab.PackagePublishFile(rn, pkg, tag, path)
+			linkType = gitlab.PackageLinkType
+		} else {
+			url, err = lab.ProjectUploadFile(rn, path)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not upload %s", path)
+		}
+		links = append(links, &gitlab.ReleaseAssetLinkOptions{
+			Name:     gitlab.String(url[strings.LastIndex(url, "/")+1:]),
+			URL:      gitlab.String(url),
+			LinkType: &linkType,
+		})
+	}
+	return links, nil
+}
+
+// releaseAddAssetFlags adds the flags used by releaseAssetLinks
+func releaseAddAssetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayP("asset", "a", []string{}, "add an asset link given as <name>=<url>; can be specified multiple times")
+	cmd.Flags().StringArrayP("upload", "u", []string{}, "upload a file and add it as an asset link; can be specified multiple times")
+	cmd.Flags().String("package", "", "upload the files to the generic package registry under the given package name")
+}
+
+// releaseCompletion completes the remote and the tag of a release
+func releaseCompletion(cmd *cobra.Command) {
+	carapace.Gen(cmd).PositionalCompletion(
+		action.Remotes(),
+		carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+			project, _, err := parseArgsRemoteAndProject(c.Args)
+			if err != nil {
+				return carapace.ActionMessage(err.Error())
+			}
+			return action.Releases(project)
+		}),
+	)
+}
+
+func init() {
+	RootCmd.AddCommand(releaseCmd)
+}
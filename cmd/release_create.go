@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var releaseCreateCmd = &cobra.Command{
+	Use:     "create [remote] <tag>",
+	Aliases: []string{"new"},
+	Short:   "Create a new release",
+	Long: heredoc.Doc(`
+		Create a release for a tag. When the tag doesn't exist, it is
+		created from the ref given with --ref.
+
+		The release name and notes are read from the -m or -F options, or
+		from the editor otherwise. The first paragraph is the name, the tag
+		being used when it's empty, and the rest is the release notes.
+
+		Assets can be linked with --asset, or uploaded with --upload. Files
+		are uploaded to the project, or to its generic package registry
+		when --package is given, using the tag as package version.`),
+	Example: heredoc.Doc(`
+		lab release create v1.0
+		lab release create upstream v1.1 --ref main -m "Version 1.1" -m "Bug fixes"
+		lab release create v1.2 -F NOTES.md --milestone "1.2"
+		lab release create v1.2 -a "Docs=https://example.com/docs" -u lab.tar.gz
+		lab release create v1.2 -u lab.tar.gz --package lab`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, tag, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if tag == "" {
+			log.Fatal("Specify the <tag> of the release")
+		}
+
+		msgs, err := cmd.Flags().GetStringArray("message")
+		if err != nil {
+			log.Fatal(err)
+		}
+		filename, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatal(err)
+		}
+		ref, err := cmd.Flags().GetString("ref")
+		if err != nil {
+			log.Fatal(err)
+		}
+		milestones, err := cmd.Flags().GetStringSlice("milestone")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		name, notes, err := editDescription("", "", msgs, filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			name = tag
+		}
+
+		links, err := releaseAssetLinks(cmd, rn, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts := &gitlab.CreateReleaseOptions{
+			Name:        &name,
+			TagName:     &tag,
+			Description: &notes,
+		}
+		if ref != "" {
+			opts.Ref = &ref
+		}
+		if len(milestones) > 0 {
+			opts.Milestones = &milestones
+		}
+		if len(links) > 0 {
+			opts.Assets = &gitlab.ReleaseAssetsOptions{Links: links}
+		}
+
+		release, err := lab.ReleaseCreate(rn, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(release.Links.Self)
+	},
+}
+
+func init() {
+	releaseCreateCmd.Flags().StringArrayP("message", "m", []string{}, "use the given <msg>; multiple -m are concatenated as separate paragraphs")
+	releaseCreateCmd.Flags().StringP("file", "F", "", "use the given file as the name and notes of the release")
+	releaseCreateCmd.Flags().StringP("ref", "r", "", "create the tag from the given branch or commit when it doesn't exist")
+	releaseCreateCmd.Flags().StringSlice("milestone", []string{}, "associate the release with the given milestone(s)")
+	releaseAddAssetFlags(releaseCreateCmd)
+	releaseCmd.AddCommand(releaseCreateCmd)
+
+	carapace.Gen(releaseCreateCmd).FlagCompletion(carapace.ActionMap{
+		"file":   carapace.ActionFiles(),
+		"upload": carapace.ActionFiles(),
+		"ref":    action.RemoteBranches(-1),
+		"milestone": carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
+			project, _, err := parseArgsRemoteAndProject(c.Args)
+			if err != nil {
+				return carapace.ActionMessage(err.Error())
+			}
+			return action.Milestones(project, action.MilestoneOpts{Active: true}).Invoke(c).FilterParts()
+		}),
+	})
+	tagCompletion(releaseCreateCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var releaseDeleteCmd = &cobra.Command{
+	Use:     "delete [remote] <tag>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a release",
+	Long: heredoc.Doc(`
+		Delete the release of a tag. The tag itself is kept.`),
+	Example: heredoc.Doc(`
+		lab release delete v1.0
+		lab release delete upstream v1.0`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, tag, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if tag == "" {
+			log.Fatal("Specify the <tag> of the release")
+		}
+
+		err = lab.ReleaseDelete(rn, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Release %s deleted\n", tag)
+	},
+}
+
+func init() {
+	releaseCmd.AddCommand(releaseDeleteCmd)
+	releaseCompletion(releaseDeleteCmd)
+}
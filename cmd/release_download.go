@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// releaseDownload is a file to download from a release
+type releaseDownload struct {
+	name string
+	url  string
+}
+
+var releaseDownloadCmd = &cobra.Command{
+	Use:   "download [remote] [<tag>]",
+	Short: "Download the assets of a release",
+	Long: heredoc.Doc(`
+		Download the asset links of the release of a tag, or of the latest
+		release when no tag is given. Assets can be selected by name with
+		glob patterns, and the source archives can be downloaded with
+		--source.`),
+	Example: heredoc.Doc(`
+		lab release download
+		lab release download v1.0 -d dist
+		lab release download upstream v1.0 -p "*.tar.gz" -p "*.sha256"
+		lab release download v1.0 --source tar.gz`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, tag, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		dir, err := cmd.Flags().GetString("dir")
+		if err != nil {
+			log.Fatal(err)
+		}
+		patterns, err := cmd.Flags().GetStringSlice("pattern")
+		if err != nil {
+			log.Fatal(err)
+		}
+		sources, err := cmd.Flags().GetStringSlice("source")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		release, err := lab.ReleaseGet(rn, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		downloads, err := releaseDownloads(release, patterns, sources)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(downloads) == 0 {
+			log.Fatalf("No assets to download for release %s", release.TagName)
+		}
+
+		err = os.MkdirAll(dir, 0755)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, d := range downloads {
+			dst := filepath.Join(dir, d.name)
+			err = releaseDownloadFile(d.url, dst)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(dst)
+		}
+	},
+}
+
+// releaseDownloads returns the asset links of the release whose name
+// matches one of the patterns, all of them when none is given, followed by
+// the source archives in the given formats
+func releaseDownloads(release *gitlab.Release, patterns, formats []string) ([]releaseDownload, error) {
+	var downloads []releaseDownload
+	for _, link := range release.Assets.Links {
+		match := len(patterns) == 0
+		for _, pattern := range patterns {
+			ok, err := path.Match(pattern, link.Name)
+			if err != nil {
+				return nil, err
+			}
+			match = match || ok
+		}
+		if !match {
+			continue
+		}
+
+		url := link.URL
+		if link.DirectAssetURL != "" {
+			url = link.DirectAssetURL
+		}
+		downloads = append(downloads, releaseDownload{
+			name: filepath.Base(link.Name),
+			url:  url,
+		})
+	}
+
+	for _, format := range formats {
+		found := false
+		for _, source := range release.Assets.Sources {
+			if source.Format != format {
+				continue
+			}
+			found = true
+			downloads = append(downloads, releaseDownload{
+				name: path.Base(source.URL),
+				url:  source.URL,
+			})
+		}
+		if !found {
+			return nil, fmt.Errorf("no source archive in %s format", format)
+		}
+	}
+	return downloads, nil
+}
+
+func releaseDownloadFile(url, dst string) error {
+	r, err := lab.Download(url)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	releaseDownloadCmd.Flags().StringP("dir", "d", ".", "directory to download the assets to")
+	releaseDownloadCmd.Flags().StringSliceP("pattern", "p", []string{}, "only download the assets whose name matches the glob pattern; can be specified multiple times")
+	releaseDownloadCmd.Flags().StringSlice("source", []string{}, "download the source archive in the given format (zip, tar.gz, tar.bz2, tar)")
+	releaseCmd.AddCommand(releaseDownloadCmd)
+	carapace.Gen(releaseDownloadCmd).FlagCompletion(carapace.ActionMap{
+		"dir":    carapace.ActionDirectories(),
+		"source": carapace.ActionValues("zip", "tar.gz", "tar.bz2", "tar"),
+	})
+	releaseCompletion(releaseDownloadCmd)
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var releaseEditCmd = &cobra.Command{
+	Use:     "edit [remote] <tag>",
+	Aliases: []string{"update"},
+	Short:   "Edit or update a release",
+	Long: heredoc.Doc(`
+		Edit the name and notes of a release in the editor, or update them
+		with the -m or -F options. The other options change the milestones
+		and the asset links of the release; the editor isn't opened when
+		one of them is used.`),
+	Example: heredoc.Doc(`
+		lab release edit v1.0
+		lab release edit upstream v1.0 -m "Version 1.0" -m "New notes"
+		lab release edit v1.0 -F NOTES.md
+		lab release edit v1.0 --milestone "1.0" --milestone "1.0.1"
+		lab release edit v1.0 -u lab.tar.gz --remove-asset lab.zip`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, tag, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if tag == "" {
+			log.Fatal("Specify the <tag> of the release")
+		}
+
+		msgs, err := cmd.Flags().GetStringArray("message")
+		if err != nil {
+			log.Fatal(err)
+		}
+		filename, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Fatal(err)
+		}
+		milestones, err := cmd.Flags().GetStringSlice("milestone")
+		if err != nil {
+			log.Fatal(err)
+		}
+		removeAssets, err := cmd.Flags().GetStringSlice("remove-asset")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		release, err := lab.ReleaseGet(rn, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		name, notes := release.Name, release.Description
+		if len(msgs) > 0 || filename != "" || cmd.Flags().NFlag() == 0 {
+			name, notes, err = editDescription(name, notes, msgs, filename)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if name == "" {
+				name = tag
+			}
+		}
+
+		opts := &gitlab.UpdateReleaseOptions{
+			Name:        &name,
+			Description: &notes,
+		}
+		if cmd.Flags().Lookup("milestone").Changed {
+			opts.Milestones = &milestones
+		}
+		_, err = lab.ReleaseUpdate(rn, tag, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, assetName := range removeAssets {
+			found := false
+			for _, link := range release.Assets.Links {
+				if link.Name != assetName {
+					continue
+				}
+				found = true
+				err = lab.ReleaseLinkDelete(rn, tag, link.ID)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}
+			if !found {
+				log.Fatalf("Release %s has no asset named %s", tag, assetName)
+			}
+		}
+
+		links, err := releaseAssetLinks(cmd, rn, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, link := range links {
+			_, err = lab.ReleaseLinkCreate(rn, tag, &gitlab.CreateReleaseLinkOptions{
+				Name:     link.Name,
+				URL:      link.URL,
+				LinkType: link.LinkType,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		fmt.Println(release.Links.Self)
+	},
+}
+
+func init() {
+	releaseEditCmd.Flags().StringArrayP("message", "m", []string{}, "use the given <msg>; multiple -m are concatenated as separate paragraphs")
+	releaseEditCmd.Flags().StringP("file", "F", "", "use the given file as the name and notes of the release")
+	releaseEditCmd.Flags().StringSlice("milestone", []string{}, "set the milestone(s) of the release; an empty value removes them all")
+	releaseEditCmd.Flags().StringSlice("remove-asset", []string{}, "remove the asset link with the given name")
+	releaseAddAssetFlags(releaseEditCmd)
+	releaseCmd.AddCommand(releaseEditCmd)
+
+	carapace.Gen(releaseEditCmd).FlagCompletion(carapace.ActionMap{
+		"file":   carapace.ActionFiles(),
+		"upload": carapace.ActionFiles(),
+		"milestone": carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
+			project, _, err := parseArgsRemoteAndProject(c.Args)
+			if err != nil {
+				return carapace.ActionMessage(err.Error())
+			}
+			return action.Milestones(project, action.MilestoneOpts{Active: true}).Invoke(c).FilterParts()
+		}),
+	})
+	releaseCompletion(releaseEditCmd)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var releaseListCmd = &cobra.Command{
+	Use:     "list [remote]",
+	Aliases: []string{"ls"},
+	Short:   "List the releases of a project",
+	Example: heredoc.Doc(`
+		lab release list
+		lab release list upstream -n 5`),
+	Args:             cobra.MaximumNArgs(1),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, _, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		number, err := cmd.Flags().GetString("number")
+		if err != nil {
+			log.Fatal(err)
+		}
+		num, err := strconv.Atoi(number)
+		if err != nil || num == 0 {
+			num = -1
+		}
+
+		releases, err := lab.ReleaseList(rn, num)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, byte(' '), 0)
+		for _, release := range releases {
+			date := ""
+			if release.ReleasedAt != nil {
+				date = release.ReleasedAt.Format("2006-01-02")
+			}
+			upcoming := ""
+			if release.UpcomingRelease {
+				upcoming = " (upcoming)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s%s\n", release.TagName, date, release.Name, upcoming)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	releaseListCmd.Flags().StringP("number", "n", "-1", "number of releases to return (all by default)")
+	releaseCmd.AddCommand(releaseListCmd)
+	carapace.Gen(releaseListCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/charmbracelet/glamour"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var releaseShowCmd = &cobra.Command{
+	Use:     "show [remote] [<tag>]",
+	Aliases: []string{"get"},
+	Short:   "Describe a release",
+	Long: heredoc.Doc(`
+		Describe the release of a tag, or the latest release of the project
+		when no tag is given.`),
+	Example: heredoc.Doc(`
+		lab release show
+		lab release show v1.0
+		lab release show upstream v1.0 -M`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, tag, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		release, err := lab.ReleaseGet(rn, tag)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		renderMarkdown := false
+		if isOutputTerminal() {
+			noMarkdown, err := cmd.Flags().GetBool("no-markdown")
+			if err != nil {
+				log.Fatal(err)
+			}
+			renderMarkdown = !noMarkdown
+		}
+
+		pager := newPager(cmd.Flags())
+		defer pager.Close()
+
+		printRelease(release, renderMarkdown)
+	},
+}
+
+func printRelease(release *gitlab.Release, renderMarkdown bool) {
+	notes := release.Description
+	if renderMarkdown {
+		r, err := getTermRenderer(glamour.WithAutoStyle())
+		if err != nil {
+			log.Fatal(err)
+		}
+		notes, _ = r.Render(notes)
+	}
+
+	released := "None"
+	if release.ReleasedAt != nil {
+		released = release.ReleasedAt.String()
+		if release.UpcomingRelease {
+			released += " (upcoming)"
+		}
+	}
+
+	milestones := make([]string, len(release.Milestones))
+	for i, m := range release.Milestones {
+		milestones[i] = m.Title
+	}
+
+	var assets strings.Builder
+	for _, link := range release.Assets.Links {
+		url := link.URL
+		if link.DirectAssetURL != "" {
+			url = link.DirectAssetURL
+		}
+		fmt.Fprintf(&assets, "\n  %s: %s", link.Name, url)
+	}
+	for _, source := range release.Assets.Sources {
+		fmt.Fprintf(&assets, "\n  source (%s): %s", source.Format, source.URL)
+	}
+
+	fmt.Printf(
+		heredoc.Doc(`%s %s
+			===================================
+			%s
+			-----------------------------------
+			Tag: %s
+			Commit: %s
+			Author: %s
+			Released: %s
+			Milestones: %s
+			Assets:%s
+			WebURL: %s
+		`),
+		release.TagName, release.Name, notes, release.TagName,
+		release.Commit.ShortID, release.Author.Username, released,
+		strings.Join(milestones, ", "), assets.String(), release.Links.Self,
+	)
+}
+
+func init() {
+	releaseShowCmd.Flags().BoolP("no-markdown", "M", false, "don't use markdown renderer to print the release notes")
+	releaseCmd.AddCommand(releaseShowCmd)
+	releaseCompletion(releaseShowCmd)
+}
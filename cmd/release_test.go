@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_parseReleaseAsset(t *testing.T) {
+	tests := []struct {
+		asset string
+		name  string
+		url   string
+		err   bool
+	}{
+		{"Docs=https://example.com/docs", "Docs", "https://example.com/docs", false},
+		{"https://example.com/dist/lab.tar.gz", "lab.tar.gz", "https://example.com/dist/lab.tar.gz", false},
+		{"https://example.com/dist/", "dist", "https://example.com/dist/", false},
+		{"https://example.com/download?file=lab.zip", "download?file=lab.zip", "https://example.com/download?file=lab.zip", false},
+		{"lab.tar.gz", "", "", true},
+		{"Docs=docs", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.asset, func(t *testing.T) {
+			name, url, err := parseReleaseAsset(test.asset)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.url, url)
+		})
+	}
+}
+
+func Test_releaseDownloads(t *testing.T) {
+	release := &gitlab.Release{TagName: "v1.0"}
+	release.Assets.Links = []*gitlab.ReleaseLink{
+		{Name: "lab.tar.gz", URL: "https://example.com/lab.tar.gz"},
+		{Name: "lab.zip", URL: "https://example.com/1", DirectAssetURL: "https://example.com/lab.zip"},
+		{Name: "checksums.sha256", URL: "https://example.com/checksums.sha256"},
+	}
+	release.Assets.Sources = append(release.Assets.Sources, struct {
+		Format string `json:"format"`
+		URL    string `json:"url"`
+	}{"tar.gz", "https://example.com/-/archive/v1.0/lab-v1.0.tar.gz"})
+
+	downloads, err := releaseDownloads(release, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []releaseDownload{
+		{"lab.tar.gz", "https://example.com/lab.tar.gz"},
+		{"lab.zip", "https://example.com/lab.zip"},
+		{"checksums.sha256", "https://example.com/checksums.sha256"},
+	}, downloads)
+
+	downloads, err = releaseDownloads(release, []string{"*.zip", "*.sha256"}, []string{"tar.gz"})
+	require.NoError(t, err)
+	assert.Equal(t, []releaseDownload{
+		{"lab.zip", "https://example.com/lab.zip"},
+		{"checksums.sha256", "https://example.com/checksums.sha256"},
+		{"lab-v1.0.tar.gz", "https://example.com/-/archive/v1.0/lab-v1.0.tar.gz"},
+	}, downloads)
+
+	_, err = releaseDownloads(release, nil, []string{"zip"})
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -199,7 +200,8 @@ func guessDefaultRemote() string {
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
-func Execute(initSkipped bool) {
+// The commands get ctx, canceled on interrupt, through cmd.Context().
+func Execute(ctx context.Context, initSkipped bool) {
 	// Try to gather remote information if running inside a git tree/repo.
 	// Otherwise, skip it, since the info won't be used at all, also avoiding
 	// misleading error/warning messages about missing remote.
@@ -223,7 +225,7 @@ func Execute(initSkipped bool) {
 	scmd, _, _ := cmd.Find(os.Args)
 	setCommandPrefix(scmd)
 
-	if err := RootCmd.Execute(); err != nil {
+	if err := RootCmd.ExecuteContext(ctx); err != nil {
 		// Execute has already logged the error
 		os.Exit(1)
 	}
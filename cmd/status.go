@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// statusConcurrency is the number of merge requests whose details are
+// fetched at the same time
+const statusConcurrency = 8
+
+// statusSection is a part of the status dashboard, its rows being
+// aligned in columns
+type statusSection struct {
+	title string
+	rows  [][]string
+	err   error
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show a dashboard of your work across all the projects",
+	Long: heredoc.Doc(`
+		Show, for the authenticated user and across all the projects of the
+		instance: the open merge requests you authored with their pipeline
+		and approval state, the merge requests awaiting your review, the
+		open issues assigned to you, your pending todos, and the branches
+		whose latest pipeline you triggered recently failed, in the projects
+		of the merge requests you authored.
+
+		Everything is fetched concurrently; a section that can't be fetched
+		shows its error without hiding the other ones.`),
+	Example: heredoc.Doc(`
+		lab status
+		lab status -n 5
+		lab status --days 7`),
+	Args:             cobra.NoArgs,
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		numRet, _ := cmd.Flags().GetString("number")
+		num, err := strconv.Atoi(numRet)
+		if err != nil || num <= 0 {
+			num = -1
+		}
+		daysRet, _ := cmd.Flags().GetString("days")
+		days, err := strconv.Atoi(daysRet)
+		if err != nil || days <= 0 {
+			log.Fatalf("invalid number of days %q", daysRet)
+		}
+
+		userID, err := lab.UserID()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// the failed pipelines are looked for in the projects of the
+		// authored merge requests, so they are fetched together
+		var (
+			authored, review, issues, todos, pipelines statusSection
+			wg                                         sync.WaitGroup
+		)
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			authored, pipelines = statusAuthoredMRs(userID, num, days)
+		}()
+		go func() {
+			defer wg.Done()
+			review = statusReviewMRs(userID, num)
+		}()
+		go func() {
+			defer wg.Done()
+			issues = statusAssignedIssues(userID, num)
+		}()
+		go func() {
+			defer wg.Done()
+			todos = statusTodos(num)
+		}()
+		wg.Wait()
+
+		pager := newPager(cmd.Flags())
+		defer pager.Close()
+
+		for i, section := range []statusSection{authored, review, issues, todos, pipelines} {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(section.String())
+		}
+	},
+}
+
+// String renders the section with its title, its rows indented and
+// aligned, or its error
+func (s statusSection) String() string {
+	var b bytes.Buffer
+	if s.err != nil {
+		fmt.Fprintf(&b, "%s\n  error: %s\n", s.title, s.err)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s (%d)\n", s.title, len(s.rows))
+	if len(s.rows) == 0 {
+		fmt.Fprintln(&b, "  none")
+		return b.String()
+	}
+	w := tabwriter.NewWriter(&b, 2, 4, 2, byte(' '), 0)
+	for _, row := range s.rows {
+		fmt.Fprintf(w, "  %s\n", strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// statusAuthoredMRs returns the open merge requests of the user with their
+// pipeline and approval state, and the failed pipelines of the user in the
+// projects of these merge requests
+func statusAuthoredMRs(userID, num, days int) (statusSection, statusSection) {
+	authored := statusSection{title: "Merge requests you authored"}
+	pipelines := statusSection{title: "Recently failed pipelines"}
+
+	mrs, err := lab.GlobalMRList(gitlab.ListMergeRequestsOptions{
+		State:    gitlab.String("opened"),
+		Scope:    gitlab.String("all"),
+		AuthorID: &userID,
+	}, num)
+	if err != nil {
+		authored.err = err
+		pipelines.err = err
+		return authored, pipelines
+	}
+
+	// every merge request needs two more requests, limit how many run at
+	// the same time when all of them are shown
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, statusConcurrency)
+	authored.rows = make([][]string, len(mrs))
+	for i, mr := range mrs {
+		wg.Add(1)
+		go func(i int, mr *gitlab.BasicMergeRequest) {
+			sem <- struct{}{}
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			authored.rows[i] = []string{mrFullReference(mr), mr.Title, statusMRPipeline(mr), statusMRApprovals(mr)}
+		}(i, mr)
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	projects := statusProjects(mrs)
+	failed := make([][]*gitlab.PipelineInfo, len(projects))
+	errs := make([]error, len(projects))
+	for i, project := range projects {
+		wg.Add(1)
+		go func(i int, project string) {
+			defer wg.Done()
+			list, err := lab.PipelineList(project, gitlab.ListProjectPipelinesOptions{
+				Username:     gitlab.String(lab.User()),
+				UpdatedAfter: &since,
+				OrderBy:      gitlab.String("id"),
+				Sort:         gitlab.String("desc"),
+			}, -1)
+			failed[i], errs[i] = latestFailedPipelines(list), err
+		}(i, project)
+	}
+	wg.Wait()
+
+	for i, project := range projects {
+		if errs[i] != nil {
+			pipelines.err = errs[i]
+			break
+		}
+		for _, p := range failed[i] {
+			pipelines.rows = append(pipelines.rows, []string{project, p.Ref, statusAge(p.UpdatedAt), p.WebURL})
+		}
+	}
+	return authored, pipelines
+}
+
+func statusMRPipeline(mr *gitlab.BasicMergeRequest) string {
+	details, err := lab.MRGet(mr.ProjectID, mr.IID)
+	if err != nil {
+		return "pipeline: unknown"
+	}
+	if details.HeadPipeline == nil {
+		return "no pipeline"
+	}
+	return "pipeline: " + details.HeadPipeline.Status
+}
+
+func statusMRApprovals(mr *gitlab.BasicMergeRequest) string {
+	if mr.Draft {
+		return "draft"
+	}
+	approvals, err := lab.GetMRApprovalsConfiguration(mr.ProjectID, mr.IID)
+	if err != nil {
+		return "approvals: unknown"
+	}
+	if approvals.Approved {
+		return "approved"
+	}
+	return fmt.Sprintf("approvals: %d/%d", len(approvals.ApprovedBy), approvals.ApprovalsRequired)
+}
+
+func statusReviewMRs(userID, num int) statusSection {
+	section := statusSection{title: "Merge requests awaiting your review"}
+	mrs, err := lab.GlobalMRList(gitlab.ListMergeRequestsOptions{
+		State:      gitlab.String("opened"),
+		Scope:      gitlab.String("all"),
+		ReviewerID: gitlab.ReviewerID(userID),
+	}, num)
+	if err != nil {
+		section.err = err
+		return section
+	}
+	for _, mr := range mrs {
+		author := ""
+		if mr.Author != nil {
+			author = mr.Author.Username
+		}
+		section.rows = append(section.rows, []string{mrFullReference(mr), mr.Title, author, statusAge(mr.UpdatedAt)})
+	}
+	return section
+}
+
+func statusAssignedIssues(userID, num int) statusSection {
+	section := statusSection{title: "Issues assigned to you"}
+	issues, err := lab.GlobalIssueList(gitlab.ListIssuesOptions{
+		State:      gitlab.String("opened"),
+		Scope:      gitlab.String("all"),
+		AssigneeID: gitlab.AssigneeID(userID),
+	}, num)
+	if err != nil {
+		section.err = err
+		return section
+	}
+	for _, issue := range issues {
+		section.rows = append(section.rows, []string{issueFullReference(issue), issue.Title, statusAge(issue.UpdatedAt)})
+	}
+	return section
+}
+
+func statusTodos(num int) statusSection {
+	section := statusSection{title: "Pending todos"}
+	todos, err := lab.TodoList(gitlab.ListTodosOptions{
+		State: gitlab.String("pending"),
+	}, num)
+	if err != nil {
+		section.err = err
+		return section
+	}
+	for _, todo := range todos {
+		section.rows = append(section.rows, []string{todoReference(todo), todoTitle(todo), strings.Replace(string(todo.ActionName), "_", " ", -1)})
+	}
+	return section
+}
+
+// todoReference returns the full reference of the target of a todo
+func todoReference(todo *gitlab.Todo) string {
+	if todo.Target == nil || todo.Project == nil {
+		return todo.TargetURL
+	}
+	sep := "#"
+	if todo.TargetType == "MergeRequest" {
+		sep = "!"
+	}
+	return fmt.Sprintf("%s%s%d", todo.Project.PathWithNamespace, sep, todo.Target.IID)
+}
+
+func todoTitle(todo *gitlab.Todo) string {
+	if todo.Target == nil {
+		return ""
+	}
+	return todo.Target.Title
+}
+
+// statusProjects returns the paths of the projects of the merge requests,
+// in the order they first appear
+func statusProjects(mrs []*gitlab.BasicMergeRequest) []string {
+	var projects []string
+	for _, mr := range mrs {
+		ref := mrFullReference(mr)
+		i := strings.LastIndex(ref, "!")
+		if i <= 0 {
+			continue
+		}
+		if project := ref[:i]; !contains(projects, project) {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// latestFailedPipelines returns the pipelines that failed and are the
+// latest of their ref, the pipelines being ordered from the most recent
+func latestFailedPipelines(pipelines []*gitlab.PipelineInfo) []*gitlab.PipelineInfo {
+	var (
+		failed []*gitlab.PipelineInfo
+		seen   = make(map[string]bool)
+	)
+	for _, p := range pipelines {
+		if seen[p.Ref] {
+			continue
+		}
+		seen[p.Ref] = true
+		if p.Status == "failed" {
+			failed = append(failed, p)
+		}
+	}
+	return failed
+}
+
+// statusAge describes how long ago t was, in the largest unit
+func statusAge(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	d := time.Since(*t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+func init() {
+	statusCmd.Flags().StringP("number", "n", "10", "number of items to show in each section, 0 for all")
+	statusCmd.Flags().String("days", "3", "number of days to look back for failed pipelines")
+	RootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_statusSectionString(t *testing.T) {
+	s := statusSection{
+		title: "Issues assigned to you",
+		rows: [][]string{
+			{"group/project#1", "A title", "2h ago"},
+			{"group/other#12", "Another title", "3d ago"},
+		},
+	}
+	assert.Equal(t, "Issues assigned to you (2)\n"+
+		"  group/project#1  A title        2h ago\n"+
+		"  group/other#12   Another title  3d ago\n", s.String())
+
+	s = statusSection{title: "Pending todos"}
+	assert.Equal(t, "Pending todos (0)\n  none\n", s.String())
+
+	s = statusSection{title: "Pending todos", err: errors.New("403 Forbidden")}
+	assert.Equal(t, "Pending todos\n  error: 403 Forbidden\n", s.String())
+}
+
+func Test_latestFailedPipelines(t *testing.T) {
+	pipelines := []*gitlab.PipelineInfo{
+		{ID: 6, Ref: "feature", Status: "failed"},
+		{ID: 5, Ref: "fix", Status: "success"},
+		{ID: 4, Ref: "fix", Status: "failed"},
+		{ID: 3, Ref: "feature", Status: "success"},
+		{ID: 2, Ref: "other", Status: "failed"},
+	}
+	var ids []int
+	for _, p := range latestFailedPipelines(pipelines) {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []int{6, 2}, ids)
+}
+
+func Test_statusProjects(t *testing.T) {
+	mrs := []*gitlab.BasicMergeRequest{
+		{IID: 1, References: &gitlab.IssueReferences{Full: "group/a!1"}},
+		{IID: 2, References: &gitlab.IssueReferences{Full: "group/b!2"}},
+		{IID: 3, References: &gitlab.IssueReferences{Full: "group/a!3"}},
+		{IID: 4},
+	}
+	assert.Equal(t, []string{"group/a", "group/b"}, statusProjects(mrs))
+}
+
+func Test_todoReference(t *testing.T) {
+	todo := &gitlab.Todo{
+		Project:    &gitlab.BasicProject{PathWithNamespace: "group/project"},
+		TargetType: "MergeRequest",
+		Target:     &gitlab.TodoTarget{IID: 7},
+		TargetURL:  "https://gitlab.com/group/project/-/merge_requests/7",
+	}
+	assert.Equal(t, "group/project!7", todoReference(todo))
+
+	todo.TargetType = "Issue"
+	assert.Equal(t, "group/project#7", todoReference(todo))
+
+	todo.Target = nil
+	assert.Equal(t, todo.TargetURL, todoReference(todo))
+}
+
+func Test_statusAge(t *testing.T) {
+	ago := func(d time.Duration) *time.Time {
+		t := time.Now().Add(-d)
+		return &t
+	}
+	assert.Equal(t, "5m ago", statusAge(ago(5*time.Minute+time.Second)))
+	assert.Equal(t, "3h ago", statusAge(ago(3*time.Hour+time.Second)))
+	assert.Equal(t, "2d ago", statusAge(ago(49*time.Hour)))
+	assert.Equal(t, "", statusAge(nil))
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+)
+
+var tagCmd = &cobra.Command{
+	Use:              "tag",
+	Short:            "Manage the tags of a project",
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// tagCompletion completes the remote and the name of a tag
+func tagCompletion(cmd *cobra.Command) {
+	carapace.Gen(cmd).PositionalCompletion(
+		action.Remotes(),
+		carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+			project, _, err := parseArgsRemoteAndProject(c.Args)
+			if err != nil {
+				return carapace.ActionMessage(err.Error())
+			}
+			return action.Tags(project)
+		}),
+	)
+}
+
+func init() {
+	RootCmd.AddCommand(tagCmd)
+}
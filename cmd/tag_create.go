@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var tagCreateCmd = &cobra.Command{
+	Use:     "create [remote] <tag>",
+	Aliases: []string{"new"},
+	Short:   "Create a new tag",
+	Long: heredoc.Doc(`
+		Create a tag pointing to the given branch or commit, or to the
+		default branch of the project. The tag is annotated when a message
+		is given.`),
+	Example: heredoc.Doc(`
+		lab tag create v1.0
+		lab tag create upstream v1.0 --ref release-1.0
+		lab tag create v1.0 -r 54fd0af3 -m "Version 1.0"`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <tag>")
+		}
+
+		ref, err := cmd.Flags().GetString("ref")
+		if err != nil {
+			log.Fatal(err)
+		}
+		msgs, err := cmd.Flags().GetStringArray("message")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if ref == "" {
+			project, err := lab.FindProject(rn)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ref = project.DefaultBranch
+		}
+
+		tag, err := lab.TagCreate(rn, name, ref, strings.Join(msgs, "\n\n"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Tag %s created at %s\n", tag.Name, tagCommitID(tag))
+	},
+}
+
+func init() {
+	tagCreateCmd.Flags().StringP("ref", "r", "", "branch or commit to create the tag from (default branch by default)")
+	tagCreateCmd.Flags().StringArrayP("message", "m", []string{}, "create an annotated tag with the given <msg>; multiple -m are concatenated as separate paragraphs")
+	tagCmd.AddCommand(tagCreateCmd)
+
+	carapace.Gen(tagCreateCmd).FlagCompletion(carapace.ActionMap{
+		"ref": action.RemoteBranches(-1),
+	})
+	carapace.Gen(tagCreateCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
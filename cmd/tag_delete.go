@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var tagDeleteCmd = &cobra.Command{
+	Use:     "delete [remote] <tag>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a tag",
+	Example: heredoc.Doc(`
+		lab tag delete v1.0
+		lab tag delete upstream v1.0`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <tag>")
+		}
+
+		err = lab.TagDelete(rn, name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Tag %s deleted\n", name)
+	},
+}
+
+func init() {
+	tagCmd.AddCommand(tagDeleteCmd)
+	tagCompletion(tagDeleteCmd)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"github.com/zaquestion/lab/internal/action"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var tagListCmd = &cobra.Command{
+	Use:     "list [remote] [search]",
+	Aliases: []string{"ls", "search"},
+	Short:   "List the tags of a project",
+	Long: heredoc.Doc(`
+		List the tags of a project, along with the commit they point to.
+		Protected tags are marked with "protected" and tags with a release
+		with "release".`),
+	Example: heredoc.Doc(`
+		lab tag list
+		lab tag list upstream v1.
+		lab tag list -n 10 --order-by version`),
+	Args:             cobra.MaximumNArgs(2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, search, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		number, err := cmd.Flags().GetString("number")
+		if err != nil {
+			log.Fatal(err)
+		}
+		num, err := strconv.Atoi(number)
+		if err != nil || num == 0 {
+			num = -1
+		}
+		orderBy, err := cmd.Flags().GetString("order-by")
+		if err != nil {
+			log.Fatal(err)
+		}
+		sort, err := cmd.Flags().GetString("sort")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts := gitlab.ListTagsOptions{
+			OrderBy: &orderBy,
+			Sort:    &sort,
+		}
+		if search != "" {
+			opts.Search = &search
+		}
+
+		tags, err := lab.TagList(rn, opts, num)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, byte(' '), 0)
+		for _, tag := range tags {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", tag.Name, tagCommitID(tag),
+				strings.Join(tagMarkers(tag), ","), strings.SplitN(tag.Message, "\n", 2)[0])
+		}
+		w.Flush()
+	},
+}
+
+func tagCommitID(tag *gitlab.Tag) string {
+	if tag.Commit == nil {
+		return ""
+	}
+	return tag.Commit.ShortID
+}
+
+// tagMarkers returns the status markers of a tag
+func tagMarkers(tag *gitlab.Tag) []string {
+	var markers []string
+	if tag.Protected {
+		markers = append(markers, "protected")
+	}
+	if tag.Release != nil {
+		markers = append(markers, "release")
+	}
+	return markers
+}
+
+func init() {
+	tagListCmd.Flags().StringP("number", "n", "-1", "number of tags to return (all by default)")
+	tagListCmd.Flags().String("order-by", "updated", "order tags by name, updated or version")
+	tagListCmd.Flags().String("sort", "desc", "sort order (asc or desc)")
+	tagCmd.AddCommand(tagListCmd)
+
+	carapace.Gen(tagListCmd).FlagCompletion(carapace.ActionMap{
+		"order-by": carapace.ActionValues("name", "updated", "version"),
+		"sort":     carapace.ActionValues("asc", "desc"),
+	})
+	carapace.Gen(tagListCmd).PositionalCompletion(
+		action.Remotes(),
+	)
+}
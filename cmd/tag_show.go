@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+var tagShowCmd = &cobra.Command{
+	Use:     "show [remote] <tag>",
+	Aliases: []string{"get"},
+	Short:   "Describe a tag",
+	Example: heredoc.Doc(`
+		lab tag show v1.0
+		lab tag show upstream v1.0`),
+	Args:             cobra.RangeArgs(1, 2),
+	PersistentPreRun: labPersistentPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		rn, name, err := parseArgsRemoteAndProject(args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if name == "" {
+			log.Fatal("Specify the name of the <tag>")
+		}
+
+		tag, err := lab.TagGet(rn, name)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		release := "None"
+		if tag.Release != nil {
+			release = tag.Release.TagName
+			r, err := lab.ReleaseGet(rn, tag.Name)
+			if err != nil {
+				log.Debugln(err)
+			} else {
+				release = fmt.Sprintf("%s (%s)", r.Name, r.Links.Self)
+			}
+		}
+
+		printTag(tag, release)
+	},
+}
+
+func printTag(tag *gitlab.Tag, release string) {
+	message := tag.Message
+	if message == "" {
+		message = "(lightweight tag)"
+	}
+
+	commit, author, date := "", "", ""
+	if tag.Commit != nil {
+		commit = fmt.Sprintf("%s %s", tag.Commit.ShortID, tag.Commit.Title)
+		author = tag.Commit.AuthorName
+		if tag.Commit.CommittedDate != nil {
+			date = tag.Commit.CommittedDate.String()
+		}
+	}
+
+	protected := "No"
+	if tag.Protected {
+		protected = "Yes"
+	}
+
+	fmt.Printf(
+		heredoc.Doc(`%s
+			===================================
+			%s
+			-----------------------------------
+			Commit: %s
+			Author: %s
+			Date: %s
+			Protected: %s
+			Release: %s
+		`),
+		tag.Name, message, commit, author, date, protected, release,
+	)
+}
+
+func init() {
+	tagCmd.AddCommand(tagShowCmd)
+	tagCompletion(tagShowCmd)
+}
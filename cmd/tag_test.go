@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_tagMarkers(t *testing.T) {
+	assert.Empty(t, tagMarkers(&gitlab.Tag{Name: "v1.0"}))
+	assert.Equal(t, []string{"protected"}, tagMarkers(&gitlab.Tag{Protected: true}))
+	assert.Equal(t, []string{"protected", "release"}, tagMarkers(&gitlab.Tag{
+		Protected: true,
+		Release:   &gitlab.ReleaseNote{TagName: "v1.0"},
+	}))
+}
+
+func Test_tagCommitID(t *testing.T) {
+	assert.Equal(t, "", tagCommitID(&gitlab.Tag{}))
+	assert.Equal(t, "54fd0af3", tagCommitID(&gitlab.Tag{Commit: &gitlab.Commit{ShortID: "54fd0af3"}}))
+}
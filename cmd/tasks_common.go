@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/zaquestion/lab/internal/git"
+)
+
+// taskRegexp matches the items of Markdown task lists
+var taskRegexp = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// task is an item of a Markdown task list
+type task struct {
+	Index   int
+	Line    int
+	Checked bool
+	Text    string
+}
+
+// tasksTarget reads and writes the description of an issue or a merge
+// request holding task lists
+type tasksTarget struct {
+	get    func(rn string, id int) (string, *time.Time, error)
+	update func(rn string, id int, description string) error
+}
+
+// parseTasks returns the task list items of a description, numbered from 1,
+// ignoring the fenced code blocks
+func parseTasks(description string) []task {
+	var (
+		tasks []task
+		fence string
+	)
+	for i, line := range strings.Split(description, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		m := taskRegexp.FindStringSubmatch(strings.TrimSuffix(line, "\r"))
+		if m == nil {
+			continue
+		}
+		tasks = append(tasks, task{
+			Index:   len(tasks) + 1,
+			Line:    i,
+			Checked: m[2] != " ",
+			Text:    m[4],
+		})
+	}
+	return tasks
+}
+
+// setTasks checks or unchecks the task list items of description with the
+// given indices, leaving everything else untouched
+func setTasks(description string, indices []int, checked bool) (string, error) {
+	tasks := parseTasks(description)
+	lines := strings.Split(description, "\n")
+
+	mark := " "
+	if checked {
+		mark = "x"
+	}
+	for _, index := range indices {
+		if index < 1 || index > len(tasks) {
+			return "", errors.Errorf("no task %d, there are %d task(s)", index, len(tasks))
+		}
+		line := tasks[index-1].Line
+		lines[line] = taskRegexp.ReplaceAllString(lines[line], "${1}"+mark+"${3}${4}")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// printTasks prints the tasks and the time their description was last
+// updated, to be given to --expect-updated-at
+func printTasks(tasks []task, updatedAt *time.Time) {
+	defer fmt.Printf("Updated at %s\n", formatUpdatedAt(updatedAt))
+	if len(tasks) == 0 {
+		fmt.Println("No tasks")
+		return
+	}
+
+	done := 0
+	for _, t := range tasks {
+		mark := " "
+		if t.Checked {
+			mark = "x"
+			done++
+		}
+		fmt.Printf("%3d [%s] %s\n", t.Index, mark, t.Text)
+	}
+	fmt.Printf("%d of %d tasks completed\n", done, len(tasks))
+}
+
+// parseTasksArgs parses the "[remote] <id> <index>..." arguments of the
+// check and uncheck commands
+func parseTasksArgs(args []string) (string, int, []int, error) {
+	n := 1
+	if ok, err := git.IsRemote(args[0]); err == nil && ok && len(args) > 2 {
+		n = 2
+	}
+	rn, id, err := parseArgsRemoteAndID(args[:n])
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if id == 0 {
+		return "", 0, nil, errors.New("specify the <id> holding the tasks")
+	}
+
+	var indices []int
+	for _, arg := range args[n:] {
+		index, err := strconv.Atoi(arg)
+		if err != nil {
+			return "", 0, nil, errors.Errorf("invalid task index %s", arg)
+		}
+		indices = append(indices, index)
+	}
+	return rn, int(id), indices, nil
+}
+
+// toggleTasks checks or unchecks tasks of the target. GitLab has no
+// conditional update, so when expected is given the change is refused if
+// the target was updated since then; an edit made between the read and the
+// update can still be overwritten
+func toggleTasks(target tasksTarget, rn string, id int, indices []int, checked bool, expected *time.Time) error {
+	description, updatedAt, err := target.get(rn, id)
+	if err != nil {
+		return err
+	}
+	if expected != nil && !sameTime(expected, updatedAt) {
+		return errors.Errorf("the description was updated at %s, list the tasks again", formatUpdatedAt(updatedAt))
+	}
+	newDescription, err := setTasks(description, indices, checked)
+	if err != nil {
+		return err
+	}
+	if newDescription == description {
+		return nil
+	}
+	return target.update(rn, id, newDescription)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// formatUpdatedAt formats an update time as accepted by
+// --expect-updated-at, keeping the milliseconds of GitLab
+func formatUpdatedAt(t *time.Time) string {
+	if t == nil {
+		return "unknown"
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// tasksAddFlags adds the flags of the check and uncheck commands
+func tasksAddFlags(cmd *cobra.Command) {
+	cmd.Flags().String("expect-updated-at", "", "refuse the change if the description was updated after the time printed by tasks")
+}
+
+// tasksExpectedUpdatedAt returns the time of --expect-updated-at, if given
+func tasksExpectedUpdatedAt(cmd *cobra.Command) (*time.Time, error) {
+	value, err := cmd.Flags().GetString("expect-updated-at")
+	if err != nil || value == "" {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Errorf("invalid time %q for --expect-updated-at", value)
+	}
+	return &t, nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tasksDescription = heredoc.Doc(`
+	Steps:
+
+	- [ ] write the code
+	- [x] write the tests
+	  * [X] nested item
+	1. [ ] numbered item
+
+	` + "```" + `
+	- [ ] not a task
+	` + "```" + `
+
+	- [] not a task either
+	+ [ ] last one`)
+
+func Test_parseTasks(t *testing.T) {
+	tasks := parseTasks(tasksDescription)
+	assert.Equal(t, []task{
+		{Index: 1, Line: 2, Checked: false, Text: "write the code"},
+		{Index: 2, Line: 3, Checked: true, Text: "write the tests"},
+		{Index: 3, Line: 4, Checked: true, Text: "nested item"},
+		{Index: 4, Line: 5, Checked: false, Text: "numbered item"},
+		{Index: 5, Line: 12, Checked: false, Text: "last one"},
+	}, tasks)
+	assert.Empty(t, parseTasks("no tasks"))
+}
+
+func Test_setTasks(t *testing.T) {
+	description, err := setTasks(tasksDescription, []int{1, 5}, true)
+	require.NoError(t, err)
+	assert.Contains(t, description, "- [x] write the code\n")
+	assert.Contains(t, description, "+ [x] last one")
+	assert.Contains(t, description, "- [ ] not a task\n")
+
+	description, err = setTasks(description, []int{1, 3}, false)
+	require.NoError(t, err)
+	assert.Contains(t, description, "- [ ] write the code\n")
+	assert.Contains(t, description, "  * [ ] nested item\n")
+	assert.Contains(t, description, "+ [x] last one")
+
+	_, err = setTasks(tasksDescription, []int{6}, true)
+	assert.Error(t, err)
+	_, err = setTasks(tasksDescription, []int{0}, true)
+	assert.Error(t, err)
+}
+
+func Test_toggleTasks(t *testing.T) {
+	t1 := time.Date(2024, 6, 30, 12, 0, 0, 123000000, time.UTC)
+	t2 := t1.Add(time.Minute)
+
+	description := "- [ ] one\n- [ ] two"
+	gets := 0
+	updated := ""
+	target := tasksTarget{
+		get: func(rn string, id int) (string, *time.Time, error) {
+			gets++
+			return description, &t1, nil
+		},
+		update: func(rn string, id int, d string) error {
+			updated = d
+			return nil
+		},
+	}
+
+	require.NoError(t, toggleTasks(target, "zaquestion/test", 1, []int{2}, true, nil))
+	assert.Equal(t, "- [ ] one\n- [x] two", updated)
+	assert.Equal(t, 1, gets)
+
+	// the expected update time, as printed by tasks, matches
+	expected, err := time.Parse(time.RFC3339, formatUpdatedAt(&t1))
+	require.NoError(t, err)
+	updated = ""
+	require.NoError(t, toggleTasks(target, "zaquestion/test", 1, []int{1}, true, &expected))
+	assert.Equal(t, "- [x] one\n- [ ] two", updated)
+
+	// the description was updated since
+	updated = ""
+	err = toggleTasks(target, "zaquestion/test", 1, []int{1}, true, &t2)
+	assert.EqualError(t, err, "the description was updated at 2024-06-30T12:00:00.123Z, list the tasks again")
+	assert.Empty(t, updated)
+}
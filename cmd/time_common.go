@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+// GitLab time tracking units, using its default of 8 hours per day, 5 days
+// per week and 4 weeks per month
+var timeUnits = []struct {
+	name    string
+	seconds int
+}{
+	{"mo", 4 * 5 * 8 * 3600},
+	{"w", 5 * 8 * 3600},
+	{"d", 8 * 3600},
+	{"h", 3600},
+	{"m", 60},
+	{"s", 1},
+}
+
+var (
+	timeDurationRegexp = regexp.MustCompile(`(\d+)\s*(mo|w|d|h|m|s)`)
+	timeNoteRegexp     = regexp.MustCompile(`^(added|subtracted|deleted) ((?:\d+\s*(?:mo|w|d|h|m|s)\s*)+) of (?:time spent|spent time)`)
+)
+
+// timeIsMR returns whether a time subcommand belongs to "lab mr time"
+func timeIsMR(cmd *cobra.Command) bool {
+	return cmd.Parent().Parent().Name() == "mr"
+}
+
+// timeParseArgs returns the project and the issue or MR id of a time
+// subcommand, along with the remaining args
+func timeParseArgs(cmd *cobra.Command, args []string, trailing int) (string, int, []string) {
+	if len(args) < trailing {
+		log.Fatalf("%s requires %d argument(s)", cmd.CommandPath(), trailing)
+	}
+	idArgs, rest := args[:len(args)-trailing], args[len(args)-trailing:]
+
+	if timeIsMR(cmd) {
+		rn, id, err := parseArgsWithGitBranchMR(idArgs)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return rn, int(id), rest
+	}
+
+	rn, id, err := parseArgsRemoteAndID(idArgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if id == 0 {
+		log.Fatal("Cannot determine issue id")
+	}
+	return rn, int(id), rest
+}
+
+func timeEstimateRunFn(cmd *cobra.Command, args []string) {
+	rn, id, rest := timeParseArgs(cmd, args, 1)
+	duration := rest[0]
+	if _, err := parseTimeDuration(duration); err != nil {
+		log.Fatal(err)
+	}
+
+	var (
+		stats *gitlab.TimeStats
+		err   error
+	)
+	if timeIsMR(cmd) {
+		stats, err = lab.MRSetTimeEstimate(rn, id, duration)
+	} else {
+		stats, err = lab.IssueSetTimeEstimate(rn, id, duration)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	printTimeStats(timeIsMR(cmd), id, stats)
+}
+
+func timeSpendRunFn(cmd *cobra.Command, args []string) {
+	rn, id, rest := timeParseArgs(cmd, args, 1)
+	duration := rest[0]
+	if _, err := parseTimeDuration(duration); err != nil {
+		log.Fatal(err)
+	}
+
+	summary, err := cmd.Flags().GetString("summary")
+	if err != nil {
+		log.Fatal(err)
+	}
+	date, err := cmd.Flags().GetString("date")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	isMR := timeIsMR(cmd)
+	var stats *gitlab.TimeStats
+	if date == "" {
+		if isMR {
+			stats, err = lab.MRAddSpentTime(rn, id, duration, summary)
+		} else {
+			stats, err = lab.IssueAddSpentTime(rn, id, duration, summary)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		printTimeStats(isMR, id, stats)
+		return
+	}
+
+	// the API can't log time at a given date, but the /spend quick action
+	// can; the summary becomes the text of the note
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		log.Fatalf("invalid date %s, use the YYYY-MM-DD format", date)
+	}
+	body := fmt.Sprintf("/spend %s %s", duration, date)
+	if summary != "" {
+		body = summary + "\n\n" + body
+	}
+	if isMR {
+		_, err = lab.MRCreateNote(rn, id, &gitlab.CreateMergeRequestNoteOptions{Body: &body})
+		if err == nil {
+			var mr *gitlab.MergeRequest
+			if mr, err = lab.MRGet(rn, id); err == nil {
+				stats = mr.TimeStats
+			}
+		}
+	} else {
+		_, err = lab.IssueCreateNote(rn, id, &gitlab.CreateIssueNoteOptions{Body: &body})
+		if err == nil {
+			var issue *gitlab.Issue
+			if issue, err = lab.IssueGet(rn, id); err == nil {
+				stats = issue.TimeStats
+			}
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	printTimeStats(isMR, id, stats)
+}
+
+func timeResetRunFn(cmd *cobra.Command, args []string) {
+	rn, id, _ := timeParseArgs(cmd, args, 0)
+
+	estimate, err := cmd.Flags().GetBool("estimate")
+	if err != nil {
+		log.Fatal(err)
+	}
+	spent, err := cmd.Flags().GetBool("spent")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !estimate && !spent {
+		estimate, spent = true, true
+	}
+
+	isMR := timeIsMR(cmd)
+	var stats *gitlab.TimeStats
+	if estimate {
+		if isMR {
+			stats, err = lab.MRResetTimeEstimate(rn, id)
+		} else {
+			stats, err = lab.IssueResetTimeEstimate(rn, id)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if spent {
+		if isMR {
+			stats, err = lab.MRResetSpentTime(rn, id)
+		} else {
+			stats, err = lab.IssueResetSpentTime(rn, id)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	printTimeStats(isMR, id, stats)
+}
+
+func timeReportRunFn(cmd *cobra.Command, args []string) {
+	rn, _, err := parseArgsRemoteAndProject(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	milestone, err := cmd.Flags().GetString("milestone")
+	if err != nil {
+		log.Fatal(err)
+	}
+	labels, err := cmd.Flags().GetStringSlice("label")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if milestone == "" && len(labels) == 0 {
+		log.Fatal("--milestone or --label is required")
+	}
+	labelOpts, err := mapLabelsAsLabelOptions(rn, labels)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var (
+		milestoneOpt *string
+		labelsOpt    *gitlab.LabelOptions
+		state        = "all"
+		estimate     int
+		spent        = make(map[string]int)
+	)
+	if milestone != "" {
+		milestoneOpt = &milestone
+	}
+	if len(labelOpts) > 0 {
+		labelsOpt = &labelOpts
+	}
+
+	addDiscussions := func(discussions []*gitlab.Discussion) {
+		for user, seconds := range timeSpentByUser(discussions) {
+			spent[user] += seconds
+		}
+	}
+
+	if timeIsMR(cmd) {
+		mrs, err := lab.MRList(rn, gitlab.ListProjectMergeRequestsOptions{
+			Milestone: milestoneOpt,
+			Labels:    labelsOpt,
+			State:     &state,
+		}, -1)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, mr := range mrs {
+			if mr.TimeStats != nil {
+				estimate += mr.TimeStats.TimeEstimate
+			}
+			discussions, err := lab.MRListDiscussions(rn, mr.IID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			addDiscussions(discussions)
+		}
+	} else {
+		issues, err := lab.IssueList(rn, gitlab.ListProjectIssuesOptions{
+			Milestone: milestoneOpt,
+			Labels:    labelsOpt,
+			State:     &state,
+		}, -1)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, issue := range issues {
+			if issue.TimeStats != nil {
+				estimate += issue.TimeStats.TimeEstimate
+			}
+			discussions, err := lab.IssueListDiscussions(rn, issue.IID)
+			if err != nil {
+				log.Fatal(err)
+			}
+			addDiscussions(discussions)
+		}
+	}
+
+	printTimeReport(spent, estimate)
+}
+
+func printTimeStats(isMR bool, id int, stats *gitlab.TimeStats) {
+	ref := fmt.Sprintf("Issue #%d", id)
+	if isMR {
+		ref = fmt.Sprintf("Merge Request !%d", id)
+	}
+	if stats == nil {
+		fmt.Println(ref)
+		return
+	}
+	fmt.Printf("%s: Estimated %s, Spent %s\n", ref,
+		formatTimeDuration(stats.TimeEstimate), formatTimeDuration(stats.TotalTimeSpent))
+}
+
+func printTimeReport(spent map[string]int, estimate int) {
+	users := make([]string, 0, len(spent))
+	total := 0
+	for user, seconds := range spent {
+		users = append(users, user)
+		total += seconds
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if spent[users[i]] != spent[users[j]] {
+			return spent[users[i]] > spent[users[j]]
+		}
+		return users[i] < users[j]
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, byte(' '), 0)
+	for _, user := range users {
+		fmt.Fprintf(w, "%s\t%s\n", user, formatTimeDuration(spent[user]))
+	}
+	fmt.Fprintf(w, "Total\t%s (estimated %s)\n", formatTimeDuration(total), formatTimeDuration(estimate))
+	w.Flush()
+}
+
+// timeSpentByUser adds up the time logged by each user according to the
+// time tracking system notes. Removing the time spent resets all users,
+// while deleted time logs are accounted to the user who deleted them.
+func timeSpentByUser(discussions []*gitlab.Discussion) map[string]int {
+	spent := make(map[string]int)
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			if !note.System {
+				continue
+			}
+			if strings.HasPrefix(note.Body, "removed time spent") {
+				spent = make(map[string]int)
+				continue
+			}
+
+			m := timeNoteRegexp.FindStringSubmatch(note.Body)
+			if m == nil {
+				continue
+			}
+			seconds, err := parseTimeDuration(m[2])
+			if err != nil {
+				continue
+			}
+			if m[1] != "added" {
+				seconds = -seconds
+			}
+			spent[note.Author.Username] += seconds
+		}
+	}
+
+	for user, seconds := range spent {
+		if seconds == 0 {
+			delete(spent, user)
+		}
+	}
+	return spent
+}
+
+// parseTimeDuration parses GitLab human durations, such as "1h30m",
+// "1w 2d" or "-30m", into seconds
+func parseTimeDuration(s string) (int, error) {
+	str := strings.TrimSpace(s)
+	negative := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+
+	matches := timeDurationRegexp.FindAllStringSubmatchIndex(str, -1)
+	if len(matches) == 0 {
+		return 0, errors.Errorf("invalid duration %q", s)
+	}
+
+	seconds, end := 0, 0
+	for _, m := range matches {
+		// everything but spaces must be part of a duration
+		if strings.TrimSpace(str[end:m[0]]) != "" {
+			return 0, errors.Errorf("invalid duration %q", s)
+		}
+		end = m[1]
+
+		n, err := strconv.Atoi(str[m[2]:m[3]])
+		if err != nil {
+			return 0, errors.Errorf("invalid duration %q", s)
+		}
+		unit := str[m[4]:m[5]]
+		for _, u := range timeUnits {
+			if u.name == unit {
+				seconds += n * u.seconds
+			}
+		}
+	}
+	if strings.TrimSpace(str[end:]) != "" {
+		return 0, errors.Errorf("invalid duration %q", s)
+	}
+
+	if negative {
+		seconds = -seconds
+	}
+	return seconds, nil
+}
+
+// formatTimeDuration formats seconds the way GitLab displays durations
+func formatTimeDuration(seconds int) string {
+	if seconds == 0 {
+		return "0m"
+	}
+
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	var parts []string
+	for _, u := range timeUnits {
+		if n := seconds / u.seconds; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.name))
+			seconds -= n * u.seconds
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_parseTimeDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		seconds  int
+	}{
+		{"30m", 1800},
+		{"1h30m", 5400},
+		{"1h 30m", 5400},
+		{"1d", 8 * 3600},
+		{"1w 2d", 7 * 8 * 3600},
+		{"1mo", 20 * 8 * 3600},
+		{"-30m", -1800},
+		{"45s", 45},
+	}
+	for _, test := range tests {
+		seconds, err := parseTimeDuration(test.duration)
+		require.NoError(t, err, test.duration)
+		assert.Equal(t, test.seconds, seconds, test.duration)
+	}
+
+	for _, duration := range []string{"", "1", "h", "1x", "1h foo", "foo 1h"} {
+		_, err := parseTimeDuration(duration)
+		assert.Error(t, err, duration)
+	}
+}
+
+func Test_formatTimeDuration(t *testing.T) {
+	assert.Equal(t, "0m", formatTimeDuration(0))
+	assert.Equal(t, "1h 30m", formatTimeDuration(5400))
+	assert.Equal(t, "1w 2d", formatTimeDuration(7*8*3600))
+	assert.Equal(t, "-30m", formatTimeDuration(-1800))
+}
+
+func Test_timeSpentByUser(t *testing.T) {
+	note := func(user, body string, system bool) *gitlab.Discussion {
+		return &gitlab.Discussion{Notes: []*gitlab.Note{{
+			Body:   body,
+			System: system,
+			Author: gitlab.NoteAuthor{Username: user},
+		}}}
+	}
+
+	discussions := []*gitlab.Discussion{
+		note("alice", "added 1h of time spent at 2023-05-01", true),
+		note("alice", "removed time spent", true),
+		note("alice", "added 2h 30m of time spent at 2023-05-02", true),
+		note("bob", "added 1d of time spent", true),
+		note("bob", "subtracted 1h of time spent at 2023-05-03", true),
+		note("carol", "added 3h of time spent", false),
+		note("carol", "changed time estimate to 1w", true),
+		note("dave", "added 1h of time spent", true),
+		note("dave", "deleted 1h of spent time from 2023-05-04", true),
+	}
+
+	assert.Equal(t, map[string]int{
+		"alice": 9000,
+		"bob":   7 * 3600,
+	}, timeSpentByUser(discussions))
+}
@@ -1,12 +1,31 @@
 package cmd
 
 import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
 	"github.com/spf13/cobra"
 )
 
+// todoActions are the actions todos are created for
+var todoActions = []string{
+	"assigned", "mentioned", "build_failed", "marked", "approval_required",
+	"directly_addressed", "review_requested", "unmergeable", "member_access_requested",
+}
+
 var todoCmd = &cobra.Command{
-	Use:              "todo",
-	Short:            "Check out the todo list for MR or issues",
+	Use:   "todo",
+	Short: "Check out the todo list for MR or issues",
+	Long: heredoc.Doc(`
+		Check out the todo list for MR or issues.
+
+		Without a subcommand, triage the pending todos interactively: preview
+		their merge request or issue, mark them as done, open them in the
+		browser, check out their merge request, or filter them by action,
+		project and author.`),
+	Example: heredoc.Doc(`
+		lab todo
+		lab todo --action review_requested
+		lab todo --project my-group/my-project --author janedoe`),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		if list, _ := cmd.Flags().GetBool("list"); list {
@@ -18,13 +37,26 @@ var todoCmd = &cobra.Command{
 			return
 		}
 
-		if len(args) == 0 || len(args) > 2 {
+		if len(args) > 0 || !isOutputTerminal() {
 			cmd.Help()
 			return
 		}
+
+		var filter todoFilter
+		filter.action, _ = cmd.Flags().GetString("action")
+		filter.project, _ = cmd.Flags().GetString("project")
+		filter.author, _ = cmd.Flags().GetString("author")
+		runTodoView(filter)
 	},
 }
 
 func init() {
+	todoCmd.Flags().String("action", "", "show only the todos with the given action")
+	todoCmd.Flags().String("project", "", "show only the todos of the projects matching the given path")
+	todoCmd.Flags().String("author", "", "show only the todos of the given author")
 	RootCmd.AddCommand(todoCmd)
+
+	carapace.Gen(todoCmd).FlagCompletion(carapace.ActionMap{
+		"action": carapace.ActionValues(todoActions...),
+	})
 }
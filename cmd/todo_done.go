@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/MakeNowJust/heredoc/v2"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rsteube/carapace"
 	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
 	lab "github.com/zaquestion/lab/internal/gitlab"
 )
 
@@ -13,17 +18,45 @@ var (
 	all bool
 )
 
+// todoTargetStates are the states of the merge requests and issues todos
+// can be selected by
+var todoTargetStates = []string{"opened", "closed", "merged", "locked"}
+
 var todoDoneCmd = &cobra.Command{
-	Use:   "done",
+	Use:   "done [id]",
 	Short: "Mark todo list entry as Done",
+	Long: heredoc.Doc(`
+		Mark a todo list entry, all of them, or the ones matching the given
+		filters as Done.
+
+		The filters select the pending todos by target type, project, action,
+		author, age and state of their merge request or issue; combined, all
+		of them must match. Use --dry-run to only print the todos that would
+		be marked as Done.`),
 	Example: heredoc.Doc(`
-		lab todo done
+		lab todo done 42
 		lab todo done -a
-		lab todo done -n 10
-		lab todo done -p
-		lab todo done -t mr`),
+		lab todo done -a --dry-run
+		lab todo done --target-state merged,closed
+		lab todo done -t mr --action review_requested --older-than 30
+		lab todo done --project my-group/my-project --author janedoe --dry-run`),
+	Args:             cobra.MaximumNArgs(1),
 	PersistentPreRun: labPersistentPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// all the todos are listed to show which would be marked as done
+		if todoDoneFiltered(cmd) || (all && dryRun) {
+			if len(args) > 0 {
+				log.Fatal("cannot use filters with a todo id")
+			}
+			todoDoneMatching(cmd)
+			return
+		}
+
 		if all {
 			err := lab.TodoMarkAllDone()
 			if err != nil {
@@ -39,6 +72,10 @@ var todoDoneCmd = &cobra.Command{
 		if err != nil {
 			log.Fatal(err)
 		}
+		if dryRun {
+			fmt.Printf("Would mark %d as Done\n", toDoNum)
+			return
+		}
 		err = lab.TodoMarkDone(toDoNum)
 		if err != nil {
 			log.Fatal(err)
@@ -47,7 +84,109 @@ var todoDoneCmd = &cobra.Command{
 	},
 }
 
+// todoDoneFiltered reports whether any filter flag is given, the todos
+// matching them being marked as done instead of one or all of them
+func todoDoneFiltered(cmd *cobra.Command) bool {
+	for _, name := range []string{"type", "project", "action", "author", "older-than", "target-state"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func todoDoneMatching(cmd *cobra.Command) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	todoType, _ := cmd.Flags().GetString("type")
+	project, _ := cmd.Flags().GetString("project")
+	action, _ := cmd.Flags().GetString("action")
+	author, _ := cmd.Flags().GetString("author")
+	states, _ := cmd.Flags().GetStringSlice("target-state")
+
+	for _, s := range states {
+		if !contains(todoTargetStates, s) {
+			log.Fatalf("unknown target state %q, use %s", s, strings.Join(todoTargetStates, ", "))
+		}
+	}
+	days, err := bulkAge(cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the type, project, action and author are filtered by the API, the
+	// state of the targets and the age by lab
+	opts := gitlab.ListTodosOptions{
+		State: gitlab.String("pending"),
+		Type:  todoTargetType(todoType),
+	}
+	if project != "" {
+		p, err := lab.GetProject(project)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.ProjectID = &p.ID
+	}
+	if action != "" {
+		a := gitlab.TodoAction(action)
+		opts.Action = &a
+	}
+	if author != "" {
+		opts.AuthorID = getUserID(author)
+		if opts.AuthorID == nil {
+			log.Fatalf("%s user not found\n", author)
+		}
+	}
+	filter := todoFilter{targetStates: states}
+	if cmd.Flags().Changed("older-than") {
+		filter.createdBefore = time.Now().AddDate(0, 0, -days)
+	}
+
+	todos, err := lab.TodoList(opts, -1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	count, failed := 0, 0
+	for _, todo := range todos {
+		if !filter.match(todo) {
+			continue
+		}
+		count++
+		if dryRun {
+			fmt.Printf("Would mark %d %s as Done\n", todo.ID, todoReference(todo))
+			continue
+		}
+		err = lab.TodoMarkDone(todo.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%d %s: %s\n", todo.ID, todoReference(todo), err)
+			failed++
+			continue
+		}
+		fmt.Println(todo.ID, todoReference(todo), "marked as Done")
+	}
+	if count == 0 {
+		fmt.Println("No Todo entry matches")
+	}
+	if failed > 0 {
+		fmt.Printf("%d Todo entries marked as Done, %d failed\n", count-failed, failed)
+		os.Exit(1)
+	}
+}
+
 func init() {
 	todoDoneCmd.Flags().BoolVarP(&all, "all", "a", false, "mark all Todos as Done")
+	todoDoneCmd.Flags().StringP("type", "t", "all", "mark the todos of the given type as Done (all/mr/issue)")
+	todoDoneCmd.Flags().String("project", "", "mark the todos of the given project as Done")
+	todoDoneCmd.Flags().String("action", "", "mark the todos with the given action as Done")
+	todoDoneCmd.Flags().String("author", "", "mark the todos of the given author as Done")
+	todoDoneCmd.Flags().String("older-than", "", "mark the todos created more than the given number of days ago as Done")
+	todoDoneCmd.Flags().StringSlice("target-state", []string{}, "mark the todos whose MR or issue is in the given state(s) as Done (opened/closed/merged/locked)")
+	todoDoneCmd.Flags().Bool("dry-run", false, "only print the todos that would be marked as Done")
 	todoCmd.AddCommand(todoDoneCmd)
+
+	carapace.Gen(todoDoneCmd).FlagCompletion(carapace.ActionMap{
+		"type":         carapace.ActionValues("all", "mr", "issue"),
+		"action":       carapace.ActionValues(todoActions...),
+		"target-state": carapace.ActionValues(todoTargetStates...).UniqueList(","),
+	})
 }
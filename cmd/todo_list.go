@@ -15,7 +15,6 @@ import (
 var (
 	todoType   string
 	todoNumRet string
-	targetType string
 	todoPretty bool
 	todoAll    bool
 )
@@ -117,19 +116,23 @@ func todoList(args []string) ([]*gitlab.Todo, error) {
 		},
 	}
 
-	var lstr = strings.ToLower(todoType)
-	switch {
-	case lstr == "mr":
-		targetType = "MergeRequest"
-		opts.Type = &targetType
-	case lstr == "issue":
-		targetType = "Issue"
-		opts.Type = &targetType
-	}
+	opts.Type = todoTargetType(todoType)
 
 	return lab.TodoList(opts, num)
 }
 
+// todoTargetType returns the target type of the todos of the --type flag,
+// nil standing for all of them
+func todoTargetType(t string) *string {
+	switch strings.ToLower(t) {
+	case "mr":
+		return gitlab.String("MergeRequest")
+	case "issue":
+		return gitlab.String("Issue")
+	}
+	return nil
+}
+
 func init() {
 	todoListCmd.Flags().BoolVarP(&todoPretty, "pretty", "p", false, "provide more infomation in output")
 	todoListCmd.Flags().StringVarP(
@@ -0,0 +1,401 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	lab "github.com/zaquestion/lab/internal/gitlab"
+)
+
+const todoViewHelp = "j/k: todo  enter: preview  d: done  o: browse  c: checkout  f: filter  r: refresh  q: quit"
+
+// todoFilter selects todos, an empty field matching all the todos
+type todoFilter struct {
+	action  string
+	project string
+	author  string
+
+	// targetStates are the states of the merge requests or issues of the
+	// todos, and createdBefore the date the todos were created before
+	targetStates  []string
+	createdBefore time.Time
+}
+
+// match reports whether the todo matches the filter; the project and the
+// author match on substrings
+func (f todoFilter) match(todo *gitlab.Todo) bool {
+	if f.action != "" && string(todo.ActionName) != f.action {
+		return false
+	}
+	if f.project != "" && (todo.Project == nil || !strings.Contains(todo.Project.PathWithNamespace, f.project)) {
+		return false
+	}
+	if f.author != "" && (todo.Author == nil || !strings.Contains(todo.Author.Username, strings.TrimPrefix(f.author, "@"))) {
+		return false
+	}
+	if len(f.targetStates) > 0 && (todo.Target == nil || !contains(f.targetStates, todo.Target.State)) {
+		return false
+	}
+	if !f.createdBefore.IsZero() && (todo.CreatedAt == nil || !todo.CreatedAt.Before(f.createdBefore)) {
+		return false
+	}
+	return true
+}
+
+func (f todoFilter) String() string {
+	var parts []string
+	if f.action != "" {
+		parts = append(parts, "action: "+f.action)
+	}
+	if f.project != "" {
+		parts = append(parts, "project: "+f.project)
+	}
+	if f.author != "" {
+		parts = append(parts, "author: "+f.author)
+	}
+	if len(f.targetStates) > 0 {
+		parts = append(parts, "state: "+strings.Join(f.targetStates, "/"))
+	}
+	if !f.createdBefore.IsZero() {
+		parts = append(parts, "before: "+f.createdBefore.Format("2006-01-02"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// todoText returns the main and secondary texts of a todo in the list
+func todoText(todo *gitlab.Todo) (string, string) {
+	action := strings.Replace(string(todo.ActionName), "_", " ", -1)
+	main := fmt.Sprintf("%s %s", todoReference(todo), todoTitle(todo))
+	secondary := action
+	if todo.Author != nil {
+		secondary += " by " + todo.Author.Username
+	}
+	if todo.CreatedAt != nil {
+		secondary += ", " + statusAge(todo.CreatedAt)
+	}
+	return tview.Escape(main), tview.Escape(secondary)
+}
+
+// todoView is the terminal UI to triage the todos
+type todoView struct {
+	app    *tview.Application
+	pages  *tview.Pages
+	frame  *tview.Flex
+	list   *tview.List
+	status *tview.TextView
+	todos  []*gitlab.Todo
+	shown  []*gitlab.Todo
+	filter todoFilter
+	load   func() ([]*gitlab.Todo, error)
+
+	// checkout is the merge request to check out once the view is closed
+	checkout *gitlab.Todo
+}
+
+func newTodoView(todos []*gitlab.Todo, filter todoFilter, load func() ([]*gitlab.Todo, error)) *todoView {
+	v := &todoView{
+		app:    tview.NewApplication(),
+		pages:  tview.NewPages(),
+		list:   tview.NewList().ShowSecondaryText(true),
+		status: tview.NewTextView().SetDynamicColors(true),
+		todos:  todos,
+		filter: filter,
+		load:   load,
+	}
+	v.status.SetText(todoViewHelp)
+	v.list.SetSelectedFunc(func(int, string, string, rune) {
+		v.preview()
+	})
+
+	v.frame = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.list, 0, 1, true).
+		AddItem(v.status, 1, 0, false)
+	v.frame.SetBorder(true)
+	v.pages.AddPage("todos", v.frame, true, true)
+
+	v.render()
+	v.app.SetRoot(v.pages, true).SetInputCapture(v.inputCapture)
+	return v
+}
+
+// render fills the list with the todos matching the filter, keeping the
+// selection when possible
+func (v *todoView) render() {
+	selected := v.list.GetCurrentItem()
+
+	v.shown = v.shown[:0]
+	for _, todo := range v.todos {
+		if v.filter.match(todo) {
+			v.shown = append(v.shown, todo)
+		}
+	}
+
+	v.list.Clear()
+	for _, todo := range v.shown {
+		main, secondary := todoText(todo)
+		v.list.AddItem(main, secondary, 0, nil)
+	}
+	if selected >= len(v.shown) {
+		selected = len(v.shown) - 1
+	}
+	if selected >= 0 {
+		v.list.SetCurrentItem(selected)
+	}
+
+	title := fmt.Sprintf(" Todos (%d/%d) ", len(v.shown), len(v.todos))
+	if f := v.filter.String(); f != "" {
+		title = fmt.Sprintf(" Todos (%d/%d) - %s ", len(v.shown), len(v.todos), f)
+	}
+	v.frame.SetTitle(tview.Escape(title))
+}
+
+// current returns the selected todo, if any
+func (v *todoView) current() *gitlab.Todo {
+	i := v.list.GetCurrentItem()
+	if i < 0 || i >= len(v.shown) {
+		return nil
+	}
+	return v.shown[i]
+}
+
+func (v *todoView) setError(err error) {
+	v.status.SetText("[red]" + tview.Escape(err.Error()))
+}
+
+func (v *todoView) inputCapture(event *tcell.EventKey) *tcell.EventKey {
+	front, _ := v.pages.GetFrontPage()
+	if front != "todos" {
+		if event.Key() == tcell.KeyEscape || (front == "preview" && event.Rune() == 'q') {
+			v.pages.RemovePage(front)
+			v.app.SetFocus(v.list)
+			return nil
+		}
+		return event
+	}
+
+	v.status.SetText(todoViewHelp)
+	if event.Key() == tcell.KeyEscape {
+		v.app.Stop()
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'q':
+		v.app.Stop()
+	case 'j':
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	case 'k':
+		return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+	case 'p':
+		v.preview()
+	case 'd':
+		v.done()
+	case 'o':
+		v.browse()
+	case 'c':
+		v.checkoutMR()
+	case 'f':
+		v.editFilter()
+	case 'r':
+		v.reload()
+	default:
+		return event
+	}
+	return nil
+}
+
+func (v *todoView) reload() {
+	todos, err := v.load()
+	if err != nil {
+		v.setError(err)
+		return
+	}
+	v.todos = todos
+	v.render()
+}
+
+// preview shows the target of the todo as lab mr show or lab issue show
+// would
+func (v *todoView) preview() {
+	todo := v.current()
+	if todo == nil {
+		return
+	}
+
+	text, err := todoPreview(todo)
+	if err != nil {
+		v.setError(err)
+		return
+	}
+
+	preview := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetText(tview.TranslateANSI(text))
+	preview.SetBorder(true).SetTitle(tview.Escape(fmt.Sprintf(" %s - q: back ", todoReference(todo))))
+	v.pages.AddPage("preview", preview, true, true)
+	v.app.SetFocus(preview)
+}
+
+// todoPreview returns the target of the todo as lab mr show or lab issue
+// show would print it, all the API errors being returned for the view to
+// show them
+func todoPreview(todo *gitlab.Todo) (string, error) {
+	if todo.Target == nil || todo.Project == nil {
+		return todo.Body + "\n\n" + todo.TargetURL, nil
+	}
+	project := todo.Project.PathWithNamespace
+
+	var b bytes.Buffer
+	switch todo.TargetType {
+	case "MergeRequest":
+		mr, err := lab.MRGet(project, todo.Target.IID)
+		if err != nil {
+			return "", err
+		}
+		details, err := getMRDetails(project, mr.IID)
+		if err != nil {
+			return "", err
+		}
+		err = writeMR(&b, mr, project, details, true)
+		return b.String(), err
+	case "Issue":
+		issue, err := lab.IssueGet(project, todo.Target.IID)
+		if err != nil {
+			return "", err
+		}
+		details, err := getIssueDetails(project, issue)
+		if err != nil {
+			return "", err
+		}
+		err = writeIssue(&b, issue, project, details, true)
+		return b.String(), err
+	}
+	return todo.Body + "\n\n" + todo.TargetURL, nil
+}
+
+func (v *todoView) done() {
+	todo := v.current()
+	if todo == nil {
+		return
+	}
+	err := lab.TodoMarkDone(todo.ID)
+	if err != nil {
+		v.setError(err)
+		return
+	}
+	for i, t := range v.todos {
+		if t.ID == todo.ID {
+			v.todos = append(v.todos[:i], v.todos[i+1:]...)
+			break
+		}
+	}
+	v.render()
+	v.status.SetText(fmt.Sprintf("%s marked as done", tview.Escape(todoReference(todo))))
+}
+
+func (v *todoView) browse() {
+	todo := v.current()
+	if todo == nil {
+		return
+	}
+	err := browse(todo.TargetURL)
+	if err != nil {
+		v.setError(err)
+	}
+}
+
+// checkoutMR closes the view to check out the merge request of the todo
+func (v *todoView) checkoutMR() {
+	todo := v.current()
+	if todo == nil {
+		return
+	}
+	if todo.TargetType != "MergeRequest" || todo.Target == nil || todo.Project == nil {
+		v.setError(fmt.Errorf("%s is not a merge request", todoReference(todo)))
+		return
+	}
+	v.checkout = todo
+	v.app.Stop()
+}
+
+func (v *todoView) editFilter() {
+	actions := []string{""}
+	for _, todo := range v.todos {
+		if !contains(actions, string(todo.ActionName)) {
+			actions = append(actions, string(todo.ActionName))
+		}
+	}
+	current := 0
+	for i, a := range actions {
+		if a == v.filter.action {
+			current = i
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown("Action", actions, current, nil)
+	form.AddInputField("Project", v.filter.project, 40, nil, nil)
+	form.AddInputField("Author", v.filter.author, 40, nil, nil)
+	form.AddButton("Apply", func() {
+		_, action := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		v.filter = todoFilter{
+			action:  action,
+			project: strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText()),
+			author:  strings.TrimSpace(form.GetFormItem(2).(*tview.InputField).GetText()),
+		}
+		v.pages.RemovePage("filter")
+		v.app.SetFocus(v.list)
+		v.render()
+	})
+	form.AddButton("Clear", func() {
+		v.filter = todoFilter{}
+		v.pages.RemovePage("filter")
+		v.app.SetFocus(v.list)
+		v.render()
+	})
+	form.SetBorder(true).SetTitle(" Filter todos ")
+
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 11, 0, true).
+			AddItem(nil, 0, 1, false), 60, 0, true).
+		AddItem(nil, 0, 1, false)
+	v.pages.AddPage("filter", modal, true, true)
+	v.app.SetFocus(form)
+}
+
+// runTodoView triages the todos interactively, then checks out the merge
+// request asked for, if any
+func runTodoView(filter todoFilter) {
+	load := func() ([]*gitlab.Todo, error) {
+		return lab.TodoList(gitlab.ListTodosOptions{
+			State: gitlab.String("pending"),
+		}, -1)
+	}
+	todos, err := load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	v := newTodoView(todos, filter, load)
+	func() {
+		defer recoverPanic(v.app)
+		if err := v.app.Run(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	if v.checkout != nil {
+		remote := findLocalRemote(v.checkout.Project.ID)
+		checkoutCmd.Run(checkoutCmd, []string{remote, strconv.Itoa(v.checkout.Target.IID)})
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_todoFilter(t *testing.T) {
+	todo := &gitlab.Todo{
+		ActionName: "review_requested",
+		Project:    &gitlab.BasicProject{PathWithNamespace: "group/project"},
+		Author:     &gitlab.BasicUser{Username: "janedoe"},
+	}
+
+	tests := []struct {
+		filter todoFilter
+		match  bool
+	}{
+		{todoFilter{}, true},
+		{todoFilter{action: "review_requested"}, true},
+		{todoFilter{action: "assigned"}, false},
+		{todoFilter{project: "group/"}, true},
+		{todoFilter{project: "other"}, false},
+		{todoFilter{author: "@jane"}, true},
+		{todoFilter{author: "john"}, false},
+		{todoFilter{action: "review_requested", project: "project", author: "janedoe"}, true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.match, test.filter.match(todo), "%+v", test.filter)
+	}
+
+	// todos without a project or an author only match without such filter
+	assert.False(t, todoFilter{project: "group"}.match(&gitlab.Todo{}))
+	assert.False(t, todoFilter{author: "janedoe"}.match(&gitlab.Todo{}))
+}
+
+func Test_todoFilterTarget(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	todo := &gitlab.Todo{
+		Target:    &gitlab.TodoTarget{State: "merged"},
+		CreatedAt: &created,
+	}
+
+	assert.True(t, todoFilter{targetStates: []string{"merged", "closed"}}.match(todo))
+	assert.False(t, todoFilter{targetStates: []string{"opened"}}.match(todo))
+	assert.True(t, todoFilter{createdBefore: created.AddDate(0, 0, 1)}.match(todo))
+	assert.False(t, todoFilter{createdBefore: created}.match(todo))
+
+	// todos without a target are never selected by state
+	assert.False(t, todoFilter{targetStates: []string{"merged"}}.match(&gitlab.Todo{}))
+}
+
+func Test_todoFilterString(t *testing.T) {
+	assert.Equal(t, "", todoFilter{}.String())
+	assert.Equal(t, "action: marked, author: janedoe", todoFilter{action: "marked", author: "janedoe"}.String())
+	assert.Equal(t, "state: merged/closed, before: 2024-03-01", todoFilter{
+		targetStates:  []string{"merged", "closed"},
+		createdBefore: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}.String())
+}
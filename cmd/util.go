@@ -641,6 +641,16 @@ func same(a, b []string) bool {
 	return true
 }
 
+// contains returns true if a contains x
+func contains(a []string, x string) bool {
+	for _, y := range a {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
+
 // getUser returns the userID for use with other GitLab API calls.
 func getUserID(user string) *int {
 	var (
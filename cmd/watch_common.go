@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// watchEventKinds are the kinds of events reported by the watch commands
+var watchEventKinds = []string{"note", "approval", "push", "pipeline", "state"}
+
+// watchEvent is a change noticed while watching an issue or a merge request
+type watchEvent struct {
+	Kind string
+	Text string
+}
+
+// watchNote is a note of a discussion, as needed to report new notes
+type watchNote struct {
+	ID     int
+	Author string
+	Body   string
+}
+
+// watchSnapshot is the state of an issue or a merge request at some point;
+// the fields not relevant to issues are left empty
+type watchSnapshot struct {
+	State          string
+	Notes          []watchNote
+	Approvers      []string
+	SHA            string
+	PipelineID     int
+	PipelineStatus string
+}
+
+// watchOptions are the options shared by the watch commands
+type watchOptions struct {
+	interval time.Duration
+	on       []string
+	exec     string
+	notify   bool
+}
+
+// watchNotes returns the notes written by users in the discussions, the
+// system notes being reported as other events
+func watchNotes(discussions []*gitlab.Discussion) []watchNote {
+	var notes []watchNote
+	for _, d := range discussions {
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			notes = append(notes, watchNote{ID: n.ID, Author: n.Author.Username, Body: n.Body})
+		}
+	}
+	return notes
+}
+
+// watchChanges returns the events that happened between the two snapshots
+func watchChanges(prev, cur *watchSnapshot) []watchEvent {
+	var events []watchEvent
+
+	seen := make(map[int]bool)
+	for _, n := range prev.Notes {
+		seen[n.ID] = true
+	}
+	for _, n := range cur.Notes {
+		if !seen[n.ID] {
+			events = append(events, watchEvent{"note", fmt.Sprintf("%s commented: %s", n.Author, watchSummary(n.Body))})
+		}
+	}
+
+	for _, a := range difference(cur.Approvers, prev.Approvers) {
+		events = append(events, watchEvent{"approval", fmt.Sprintf("approved by %s", a)})
+	}
+	for _, a := range difference(prev.Approvers, cur.Approvers) {
+		events = append(events, watchEvent{"approval", fmt.Sprintf("approval revoked by %s", a)})
+	}
+
+	if cur.SHA != prev.SHA && cur.SHA != "" {
+		events = append(events, watchEvent{"push", fmt.Sprintf("new commits pushed, now at %s", watchShortSHA(cur.SHA))})
+	}
+
+	if cur.PipelineID != prev.PipelineID && cur.PipelineID != 0 {
+		events = append(events, watchEvent{"pipeline", fmt.Sprintf("pipeline #%d %s", cur.PipelineID, cur.PipelineStatus)})
+	} else if cur.PipelineStatus != prev.PipelineStatus && cur.PipelineID != 0 {
+		events = append(events, watchEvent{"pipeline", fmt.Sprintf("pipeline #%d %s -> %s", cur.PipelineID, prev.PipelineStatus, cur.PipelineStatus)})
+	}
+
+	if cur.State != prev.State {
+		events = append(events, watchEvent{"state", fmt.Sprintf("%s -> %s", prev.State, cur.State)})
+	}
+	return events
+}
+
+// watchSummary returns the first line of a note, shortened when too long
+func watchSummary(body string) string {
+	line := strings.TrimSpace(strings.SplitN(strings.TrimSpace(body), "\n", 2)[0])
+	if len(line) > 72 {
+		line = line[:69] + "..."
+	}
+	return line
+}
+
+func watchShortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// watchAddFlags adds the flags shared by the watch commands
+func watchAddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("interval", "i", "30", "number of seconds between two checks")
+	cmd.Flags().StringSlice("on", watchEventKinds, "events running --exec or --notify ("+strings.Join(watchEventKinds, "/")+")")
+	cmd.Flags().StringP("exec", "e", "", "run the given shell command on each event, with LAB_EVENT and LAB_EVENT_TEXT set")
+	cmd.Flags().Bool("notify", false, "show a desktop notification on each event with notify-send")
+}
+
+func watchOptionsFromFlags(cmd *cobra.Command) (watchOptions, error) {
+	var opts watchOptions
+
+	interval, err := cmd.Flags().GetString("interval")
+	if err != nil {
+		return opts, err
+	}
+	seconds, err := strconv.Atoi(interval)
+	if err != nil || seconds < 1 {
+		return opts, errors.Errorf("invalid interval %q, use a number of seconds", interval)
+	}
+	opts.interval = time.Duration(seconds) * time.Second
+
+	opts.on, err = cmd.Flags().GetStringSlice("on")
+	if err != nil {
+		return opts, err
+	}
+	for _, kind := range opts.on {
+		if !contains(watchEventKinds, kind) {
+			return opts, errors.Errorf("unknown event %q, use %s", kind, strings.Join(watchEventKinds, ", "))
+		}
+	}
+
+	opts.exec, err = cmd.Flags().GetString("exec")
+	if err != nil {
+		return opts, err
+	}
+	opts.notify, err = cmd.Flags().GetBool("notify")
+	return opts, err
+}
+
+// watch prints the events of the snapshots taken every interval until ctx
+// is done, running the command and notifying the events asked for
+func watch(ctx context.Context, title string, snapshot func() (*watchSnapshot, error), opts watchOptions) error {
+	prev, err := snapshot()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Watching %s, press Ctrl-C to stop\n", title)
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		cur, err := snapshot()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// a failed check is retried at the next tick
+			log.Errorln(err)
+			continue
+		}
+
+		for _, event := range watchChanges(prev, cur) {
+			fmt.Printf("%s %-8s %s\n", time.Now().Format("15:04:05"), event.Kind, event.Text)
+			if !contains(opts.on, event.Kind) {
+				continue
+			}
+			if opts.exec != "" {
+				watchExec(ctx, opts.exec, event)
+			}
+			if opts.notify {
+				watchNotify(ctx, title, event)
+			}
+		}
+		prev = cur
+	}
+}
+
+func watchExec(ctx context.Context, command string, event watchEvent) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), "LAB_EVENT="+event.Kind, "LAB_EVENT_TEXT="+event.Text)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil && ctx.Err() == nil {
+		log.Errorln(err)
+	}
+}
+
+func watchNotify(ctx context.Context, title string, event watchEvent) {
+	err := exec.CommandContext(ctx, "notify-send", "lab: "+title, event.Text).Run()
+	if err != nil && ctx.Err() == nil {
+		log.Errorln(err)
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func Test_watchNotes(t *testing.T) {
+	discussions := []*gitlab.Discussion{
+		{Notes: []*gitlab.Note{
+			{ID: 1, Body: "first", Author: gitlab.NoteAuthor{Username: "alice"}},
+			{ID: 2, Body: "added 1 commit", System: true},
+			{ID: 3, Body: "reply", Author: gitlab.NoteAuthor{Username: "bob"}},
+		}},
+	}
+	assert.Equal(t, []watchNote{
+		{ID: 1, Author: "alice", Body: "first"},
+		{ID: 3, Author: "bob", Body: "reply"},
+	}, watchNotes(discussions))
+}
+
+func Test_watchChanges(t *testing.T) {
+	prev := &watchSnapshot{
+		State:          "opened",
+		Notes:          []watchNote{{ID: 1, Author: "alice", Body: "first"}},
+		Approvers:      []string{"carol"},
+		SHA:            "0123456789abcdef",
+		PipelineID:     10,
+		PipelineStatus: "running",
+	}
+
+	assert.Empty(t, watchChanges(prev, prev))
+
+	cur := &watchSnapshot{
+		State:          "merged",
+		Notes:          []watchNote{{ID: 1, Author: "alice", Body: "first"}, {ID: 4, Author: "bob", Body: "LGTM\nthanks"}},
+		Approvers:      []string{"dave"},
+		SHA:            "fedcba9876543210",
+		PipelineID:     10,
+		PipelineStatus: "failed",
+	}
+	assert.Equal(t, []watchEvent{
+		{"note", "bob commented: LGTM"},
+		{"approval", "approved by dave"},
+		{"approval", "approval revoked by carol"},
+		{"push", "new commits pushed, now at fedcba98"},
+		{"pipeline", "pipeline #10 running -> failed"},
+		{"state", "opened -> merged"},
+	}, watchChanges(prev, cur))
+
+	cur = &watchSnapshot{
+		State:          "opened",
+		Notes:          prev.Notes,
+		Approvers:      prev.Approvers,
+		SHA:            prev.SHA,
+		PipelineID:     11,
+		PipelineStatus: "pending",
+	}
+	assert.Equal(t, []watchEvent{
+		{"pipeline", "pipeline #11 pending"},
+	}, watchChanges(prev, cur))
+}
+
+func Test_watchSummary(t *testing.T) {
+	assert.Equal(t, "first line", watchSummary("\n first line \nsecond line"))
+	long := "a very long comment that goes well beyond the width of a terminal line for sure"
+	assert.Equal(t, long[:69]+"...", watchSummary(long))
+}
+
+func Test_watchOptionsFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		watchAddFlags(cmd)
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	opts, err := watchOptionsFromFlags(newCmd())
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, opts.interval)
+	assert.Equal(t, watchEventKinds, opts.on)
+
+	opts, err = watchOptionsFromFlags(newCmd("-i", "5", "--on", "pipeline,state", "--notify"))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, opts.interval)
+	assert.Equal(t, []string{"pipeline", "state"}, opts.on)
+	assert.True(t, opts.notify)
+
+	_, err = watchOptionsFromFlags(newCmd("-i", "0"))
+	assert.Error(t, err)
+	_, err = watchOptionsFromFlags(newCmd("--on", "push,merge"))
+	assert.Error(t, err)
+}
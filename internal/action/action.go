@@ -139,3 +139,37 @@ func Milestones(project string, opts MilestoneOpts) carapace.Action {
 		return carapace.ActionValuesDescribed(values...)
 	}).Cache(5*time.Minute, cache.String(project, opts.format()))
 }
+
+// Releases completes the tags of the releases of a project
+func Releases(project string) carapace.Action {
+	return carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+		releases, err := lab.ReleaseList(project, -1)
+		if err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+
+		values := make([]string, len(releases)*2)
+		for index, release := range releases {
+			values[index*2] = release.TagName
+			values[index*2+1] = release.Name
+		}
+		return carapace.ActionValuesDescribed(values...)
+	}).Cache(5*time.Minute, cache.String(project))
+}
+
+// Tags completes the tags of a project
+func Tags(project string) carapace.Action {
+	return carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+		tags, err := lab.TagList(project, gitlab.ListTagsOptions{}, -1)
+		if err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+
+		values := make([]string, len(tags)*2)
+		for index, tag := range tags {
+			values[index*2] = tag.Name
+			values[index*2+1] = strings.SplitN(tag.Message, "\n", 2)[0]
+		}
+		return carapace.ActionValuesDescribed(values...)
+	}).Cache(5*time.Minute, cache.String(project))
+}
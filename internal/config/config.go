@@ -429,6 +429,26 @@ func WriteConfigEntry(desc string, value interface{}, configpath string, confign
 	targetConfig.WriteConfig()
 }
 
+// CopyWorktreeConfig copies the worktree config of the current worktree, if
+// there's one, to the worktree whose git directory is gitDir so the same
+// settings apply there.
+func CopyWorktreeConfig(gitDir string) error {
+	configfile := WorktreeConfigName + ".toml"
+	text, err := ioutil.ReadFile(path.Join(worktreeConfigPath(), configfile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	configpath := path.Join(gitDir, WorktreeConfigName)
+	if err := os.MkdirAll(configpath, os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(configpath, configfile), text, 0600)
+}
+
 // UserConfigError returns a default error message about authentication
 func UserConfigError(host string) {
 	fmt.Printf("Error: User authentication failed.  This is likely due to a misconfigured or expired Personal Access Token.  Verify the token or token_load config settings before attempting to authenticate.  ")
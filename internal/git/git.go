@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -141,6 +142,20 @@ func Log(sha1, sha2 string) (string, error) {
 	return string(outputs) + string(diffOutput), nil
 }
 
+// FirstParentCommits returns the full hashes of the commits in the
+// first-parent history between sha1 and sha2, newest first. These are the
+// merge, squash or fast-forwarded commits that landed on the branch.
+func FirstParentCommits(sha1, sha2 string) ([]string, error) {
+	cmd := New("log", "--first-parent", "--format=%H", fmt.Sprintf("%s..%s", sha1, sha2))
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Errorf("Can't load git log %s..%s", sha1, sha2)
+	}
+	return strings.Fields(string(out)), nil
+}
+
 // CurrentBranch returns the currently checked out branch
 func CurrentBranch() (string, error) {
 	cmd := New("rev-parse", "--abbrev-ref", "HEAD")
@@ -364,3 +379,109 @@ func NumberCommits(sha1, sha2 string) int {
 	numLines := strings.Count(string(CmdOut), "\n")
 	return numLines
 }
+
+// CommitAuthor holds the author information of a single commit
+type CommitAuthor struct {
+	Name  string
+	Email string
+	Date  time.Time
+}
+
+// PathAuthors returns the authors of the commits reachable from ref that
+// touched any of the given paths, newest first. The since argument accepts
+// any format understood by "git log --since" and is ignored when empty.
+func PathAuthors(ref, since string, paths []string) ([]CommitAuthor, error) {
+	gitcmd := []string{"log", "--no-merges", "--format=%aN%x09%aE%x09%at"}
+	if since != "" {
+		gitcmd = append(gitcmd, "--since="+since)
+	}
+	gitcmd = append(gitcmd, ref, "--")
+	gitcmd = append(gitcmd, paths...)
+
+	cmd := New(gitcmd...)
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Errorf("Can't load git log for %s", ref)
+	}
+
+	var authors []CommitAuthor
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		authors = append(authors, CommitAuthor{
+			Name:  fields[0],
+			Email: fields[1],
+			Date:  time.Unix(timestamp, 0),
+		})
+	}
+	return authors, nil
+}
+
+// Worktree describes a single worktree as reported by "git worktree list"
+type Worktree struct {
+	Path     string
+	Head     string
+	Branch   string
+	Bare     bool
+	Detached bool
+}
+
+// Worktrees returns the worktrees of the current repository, the main
+// worktree first
+func Worktrees() ([]Worktree, error) {
+	cmd := New("worktree", "list", "--porcelain")
+	cmd.Stdout = nil
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(string(out)), nil
+}
+
+func parseWorktrees(out string) []Worktree {
+	var (
+		worktrees []Worktree
+		wt        *Worktree
+	)
+	for _, line := range strings.Split(out, "\n") {
+		key, value := line, ""
+		if i := strings.Index(line, " "); i >= 0 {
+			key, value = line[:i], line[i+1:]
+		}
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value})
+			wt = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			wt.Head = value
+		case "branch":
+			wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			wt.Bare = true
+		case "detached":
+			wt.Detached = true
+		}
+	}
+	return worktrees
+}
+
+// WorktreeDir returns the full path to the git directory of the worktree
+// at path
+func WorktreeDir(path string) (string, error) {
+	cmd := New("-C", path, "rev-parse", "--absolute-git-dir")
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	d, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(d)), nil
+}
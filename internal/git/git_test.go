@@ -323,3 +323,27 @@ func copyTestRepo() string {
 	}
 	return dst
 }
+
+func TestParseWorktrees(t *testing.T) {
+	out := `worktree /src/lab
+HEAD 3b2f8c0b7e1d4f6a9c5e2d1b0a9f8e7d6c5b4a32
+branch refs/heads/master
+
+worktree /src/lab-mr-10
+HEAD 9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b
+branch refs/heads/feature/x
+
+worktree /src/lab-detached
+HEAD 1234567890abcdef1234567890abcdef12345678
+detached
+
+`
+	worktrees := parseWorktrees(out)
+	require.Len(t, worktrees, 3)
+	require.Equal(t, "/src/lab", worktrees[0].Path)
+	require.Equal(t, "master", worktrees[0].Branch)
+	require.Equal(t, "feature/x", worktrees[1].Branch)
+	require.Equal(t, "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", worktrees[1].Head)
+	require.True(t, worktrees[2].Detached)
+	require.Empty(t, worktrees[2].Branch)
+}
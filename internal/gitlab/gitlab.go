@@ -39,15 +39,18 @@ var (
 	ErrNotModified = errors.New("Not Modified")
 	// ErrProjectNotFound is returned when a GitLab project cannot be found.
 	ErrProjectNotFound = errors.New("GitLab project not found, verify you have access to the requested resource")
+	// ErrFileNotFound is returned when a file cannot be found in a project repository.
+	ErrFileNotFound = errors.New("File not found in the repository")
 	// ErrStatusForbidden is returned when attempting to access a GitLab project with insufficient permissions
 	ErrStatusForbidden = errors.New("Insufficient permissions for GitLab project")
 )
 
 var (
-	lab   *gitlab.Client
-	host  string
-	user  string
-	token string
+	lab        *gitlab.Client
+	httpClient *http.Client
+	host       string
+	user       string
+	token      string
 )
 
 // Host exposes the GitLab scheme://hostname used to interact with the API
@@ -79,7 +82,7 @@ func initGitlabClient(ctx context.Context, _host, _user, _token string, tlsConfi
 
 	tp := http.DefaultTransport.(*http.Transport).Clone()
 	tp.TLSClientConfig = tlsConfig
-	httpClient := &http.Client{
+	httpClient = &http.Client{
 		Transport: tp,
 	}
 
@@ -143,8 +146,9 @@ func parseID(id interface{}) (string, error) {
 
 // Defines filepath for default GitLab templates
 const (
-	TmplMR    = "merge_request_templates/default.md"
-	TmplIssue = "issue_templates/default.md"
+	TmplMR       = "merge_request_templates/default.md"
+	TmplMRRevert = "merge_request_templates/revert.md"
+	TmplIssue    = "issue_templates/default.md"
 )
 
 // LoadGitLabTmpl loads gitlab templates for use in creating Issues and MRs
@@ -528,6 +532,80 @@ func MRListDiscussions(projID interface{}, id int) ([]*gitlab.Discussion, error)
 	return discussions, nil
 }
 
+// MRListDiffs retrieves the list of files changed by a merge request
+func MRListDiffs(projID interface{}, id int) ([]*gitlab.MergeRequestDiff, error) {
+	diffs := []*gitlab.MergeRequestDiff{}
+	opt := &gitlab.ListMergeRequestDiffsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: maxItemsPerPage,
+		},
+	}
+
+	for {
+		d, resp, err := lab.MergeRequests.ListMergeRequestDiffs(projID, id, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, d...)
+
+		var ok bool
+		if opt.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+
+	return diffs, nil
+}
+
+// MRListCommits retrieves the commits of a merge request, newest first
+func MRListCommits(projID interface{}, id int) ([]*gitlab.Commit, error) {
+	commits := []*gitlab.Commit{}
+	opt := &gitlab.GetMergeRequestCommitsOptions{
+		PerPage: maxItemsPerPage,
+	}
+
+	for {
+		c, resp, err := lab.MergeRequests.GetMergeRequestCommits(projID, id, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, c...)
+
+		var ok bool
+		if opt.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+
+	return commits, nil
+}
+
+// MRListDependencies retrieves the merge requests blocking a merge request
+func MRListDependencies(projID interface{}, id int) ([]gitlab.MergeRequestDependency, error) {
+	deps, _, err := lab.MergeRequests.GetMergeRequestDependencies(projID, id)
+	if err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// MRCreateDependency makes the merge request with the global ID blockingID
+// block the merge request id
+func MRCreateDependency(projID interface{}, id int, blockingID int) error {
+	_, _, err := lab.MergeRequests.CreateMergeRequestDependency(projID, id, gitlab.CreateMergeRequestDependencyOptions{
+		BlockingMergeRequestID: &blockingID,
+	})
+	return err
+}
+
+// MRDeleteDependency removes the dependency blockID of a merge request
+func MRDeleteDependency(projID interface{}, id int, blockID int) error {
+	_, err := lab.MergeRequests.DeleteMergeRequestDependency(projID, id, blockID)
+	return err
+}
+
 // MRRebase merges an mr on a GitLab project
 func MRRebase(projID interface{}, id int, opts *gitlab.RebaseMergeRequestOptions) error {
 	_, err := lab.MergeRequests.RebaseMergeRequest(projID, int(id), opts)
@@ -624,6 +702,38 @@ func MRThumbDown(projID interface{}, id int) error {
 	return nil
 }
 
+// MRSetTimeEstimate sets the time estimate of a merge request
+func MRSetTimeEstimate(projID interface{}, id int, duration string) (*gitlab.TimeStats, error) {
+	t, _, err := lab.MergeRequests.SetTimeEstimate(projID, id, &gitlab.SetTimeEstimateOptions{
+		Duration: &duration,
+	})
+	return t, err
+}
+
+// MRResetTimeEstimate resets the time estimate of a merge request
+func MRResetTimeEstimate(projID interface{}, id int) (*gitlab.TimeStats, error) {
+	t, _, err := lab.MergeRequests.ResetTimeEstimate(projID, id)
+	return t, err
+}
+
+// MRAddSpentTime adds spent time to a merge request
+func MRAddSpentTime(projID interface{}, id int, duration, summary string) (*gitlab.TimeStats, error) {
+	opts := &gitlab.AddSpentTimeOptions{
+		Duration: &duration,
+	}
+	if summary != "" {
+		opts.Summary = &summary
+	}
+	t, _, err := lab.MergeRequests.AddSpentTime(projID, id, opts)
+	return t, err
+}
+
+// MRResetSpentTime resets the time spent on a merge request
+func MRResetSpentTime(projID interface{}, id int) (*gitlab.TimeStats, error) {
+	t, _, err := lab.MergeRequests.ResetSpentTime(projID, id)
+	return t, err
+}
+
 // IssueCreate opens a new issue on a GitLab project
 func IssueCreate(projID interface{}, opts *gitlab.CreateIssueOptions) (string, error) {
 	mr, _, err := lab.Issues.CreateIssue(projID, opts)
@@ -698,6 +808,243 @@ func IssueList(projID interface{}, opts gitlab.ListProjectIssuesOptions, n int)
 	return list, nil
 }
 
+// GroupIssueList gets the issues of the projects of a group
+func GroupIssueList(groupID interface{}, opts gitlab.ListGroupIssuesOptions, n int) ([]*gitlab.Issue, error) {
+	var list []*gitlab.Issue
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		issues, resp, err := lab.Issues.ListGroupIssues(groupID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, issues...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// GroupMRList gets the merge requests of the projects of a group
+func GroupMRList(groupID interface{}, opts gitlab.ListGroupMergeRequestsOptions, n int) ([]*gitlab.BasicMergeRequest, error) {
+	var list []*gitlab.BasicMergeRequest
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		mrs, resp, err := lab.MergeRequests.ListGroupMergeRequests(groupID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, mrs...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// GlobalMRList gets the merge requests visible to the user across all the projects
+func GlobalMRList(opts gitlab.ListMergeRequestsOptions, n int) ([]*gitlab.BasicMergeRequest, error) {
+	var list []*gitlab.BasicMergeRequest
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		mrs, resp, err := lab.MergeRequests.ListMergeRequests(&opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, mrs...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// GlobalIssueList gets the issues visible to the user across all the projects
+func GlobalIssueList(opts gitlab.ListIssuesOptions, n int) ([]*gitlab.Issue, error) {
+	var list []*gitlab.Issue
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		issues, resp, err := lab.Issues.ListIssues(&opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, issues...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// EpicList gets the epics of a group
+func EpicList(groupID interface{}, opts gitlab.ListGroupEpicsOptions, n int) ([]*gitlab.Epic, error) {
+	var list []*gitlab.Epic
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		epics, resp, err := lab.Epics.ListGroupEpics(groupID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, epics...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// EpicGet retrieves the epic information from a GitLab group
+func EpicGet(groupID interface{}, id int) (*gitlab.Epic, error) {
+	epic, _, err := lab.Epics.GetEpic(groupID, id)
+	if err != nil {
+		return nil, err
+	}
+	return epic, nil
+}
+
+// EpicCreate opens a new epic on a GitLab group
+func EpicCreate(groupID interface{}, opts *gitlab.CreateEpicOptions) (*gitlab.Epic, error) {
+	epic, _, err := lab.Epics.CreateEpic(groupID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return epic, nil
+}
+
+// EpicUpdate edits an epic on a GitLab group
+func EpicUpdate(groupID interface{}, id int, opts *gitlab.UpdateEpicOptions) (*gitlab.Epic, error) {
+	epic, _, err := lab.Epics.UpdateEpic(groupID, id, opts)
+	if err != nil {
+		return nil, err
+	}
+	return epic, nil
+}
+
+// EpicIssues gets the issues added to an epic
+func EpicIssues(groupID interface{}, id int) ([]*gitlab.Issue, error) {
+	opts := &gitlab.ListOptions{
+		PerPage: maxItemsPerPage,
+	}
+
+	var list []*gitlab.Issue
+	for {
+		issues, resp, err := lab.EpicIssues.ListEpicIssues(groupID, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, issues...)
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// EpicAddIssue adds an issue, given by its global ID, to an epic
+func EpicAddIssue(groupID interface{}, id int, issueID int) error {
+	_, _, err := lab.EpicIssues.AssignEpicIssue(groupID, id, issueID)
+	return err
+}
+
+// IssueReorder moves an issue before the issue afterID, or after the issue
+// beforeID, in the relative order used by boards
+func IssueReorder(projID interface{}, id int, afterID, beforeID *int) error {
+	_, _, err := lab.Issues.ReorderIssue(projID, id, &gitlab.ReorderIssueOptions{
+		MoveAfterID:  afterID,
+		MoveBeforeID: beforeID,
+	})
+	return err
+}
+
+// IssueBoards gets the issue boards of a project
+func IssueBoards(projID interface{}) ([]*gitlab.IssueBoard, error) {
+	opts := &gitlab.ListIssueBoardsOptions{}
+	opts.PerPage = maxItemsPerPage
+	boards, _, err := lab.Boards.ListIssueBoards(projID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// GroupIssueBoards gets the issue boards of a group
+func GroupIssueBoards(groupID interface{}) ([]*gitlab.GroupIssueBoard, error) {
+	opts := &gitlab.ListGroupIssueBoardsOptions{}
+	opts.PerPage = maxItemsPerPage
+	boards, _, err := lab.GroupIssueBoards.ListGroupIssueBoards(groupID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
 // IssueClose closes an issue on a GitLab project
 func IssueClose(projID interface{}, id int) error {
 	issue, _, err := lab.Issues.GetIssue(projID, id)
@@ -740,6 +1087,33 @@ func IssueDuplicate(projID interface{}, id int, dupID interface{}) error {
 	return nil
 }
 
+// IssueListLinks gets the issues linked to an issue
+func IssueListLinks(projID interface{}, id int) ([]*gitlab.IssueRelation, error) {
+	relations, _, err := lab.IssueLinks.ListIssueRelations(projID, id)
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// IssueCreateLink links an issue to the issue targetID of targetProjID with
+// the given link type: relates_to, blocks or is_blocked_by
+func IssueCreateLink(projID interface{}, id int, targetProjID string, targetID int, linkType string) error {
+	targetIID := strconv.Itoa(targetID)
+	_, _, err := lab.IssueLinks.CreateIssueLink(projID, id, &gitlab.CreateIssueLinkOptions{
+		TargetProjectID: &targetProjID,
+		TargetIssueIID:  &targetIID,
+		LinkType:        &linkType,
+	})
+	return err
+}
+
+// IssueDeleteLink removes a link between two issues
+func IssueDeleteLink(projID interface{}, id int, linkID int) error {
+	_, _, err := lab.IssueLinks.DeleteIssueLink(projID, id, linkID)
+	return err
+}
+
 // IssueReopen reopens a closed issue
 func IssueReopen(projID interface{}, id int) error {
 	issue, _, err := lab.Issues.GetIssue(projID, id)
@@ -811,6 +1185,38 @@ func IssueUnsubscribe(projID interface{}, id int) error {
 	return nil
 }
 
+// IssueSetTimeEstimate sets the time estimate of an issue
+func IssueSetTimeEstimate(projID interface{}, id int, duration string) (*gitlab.TimeStats, error) {
+	t, _, err := lab.Issues.SetTimeEstimate(projID, id, &gitlab.SetTimeEstimateOptions{
+		Duration: &duration,
+	})
+	return t, err
+}
+
+// IssueResetTimeEstimate resets the time estimate of an issue
+func IssueResetTimeEstimate(projID interface{}, id int) (*gitlab.TimeStats, error) {
+	t, _, err := lab.Issues.ResetTimeEstimate(projID, id)
+	return t, err
+}
+
+// IssueAddSpentTime adds spent time to an issue
+func IssueAddSpentTime(projID interface{}, id int, duration, summary string) (*gitlab.TimeStats, error) {
+	opts := &gitlab.AddSpentTimeOptions{
+		Duration: &duration,
+	}
+	if summary != "" {
+		opts.Summary = &summary
+	}
+	t, _, err := lab.Issues.AddSpentTime(projID, id, opts)
+	return t, err
+}
+
+// IssueResetSpentTime resets the time spent on an issue
+func IssueResetSpentTime(projID interface{}, id int) (*gitlab.TimeStats, error) {
+	t, _, err := lab.Issues.ResetSpentTime(projID, id)
+	return t, err
+}
+
 // GetCommit returns top Commit by ref (hash, branch or tag).
 func GetCommit(projID interface{}, ref string, opts *gitlab.GetCommitOptions) (*gitlab.Commit, error) {
 	c, _, err := lab.Commits.GetCommit(projID, ref, opts)
@@ -820,6 +1226,53 @@ func GetCommit(projID interface{}, ref string, opts *gitlab.GetCommitOptions) (*
 	return c, nil
 }
 
+// MRsByCommit returns the merge requests that introduced a commit
+func MRsByCommit(projID interface{}, sha string) ([]*gitlab.BasicMergeRequest, error) {
+	mrs, _, err := lab.Commits.ListMergeRequestsByCommit(projID, sha)
+	if err != nil {
+		return nil, err
+	}
+	return mrs, nil
+}
+
+// CommitRevert reverts a commit by adding a new commit to the given branch
+func CommitRevert(projID interface{}, sha, branch string) (*gitlab.Commit, error) {
+	c, _, err := lab.Commits.RevertCommit(projID, sha, &gitlab.RevertCommitOptions{
+		Branch: &branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CommitCherryPick cherry-picks a commit by adding a new commit to the
+// given branch
+func CommitCherryPick(projID interface{}, sha, branch string) (*gitlab.Commit, error) {
+	c, _, err := lab.Commits.CherryPickCommit(projID, sha, &gitlab.CherryPickCommitOptions{
+		Branch: &branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetRawFile returns the raw content of a file in the repository at the
+// given ref. ErrFileNotFound is returned when the file doesn't exist.
+func GetRawFile(projID interface{}, file, ref string) ([]byte, error) {
+	content, resp, err := lab.RepositoryFiles.GetRawFile(projID, file, &gitlab.GetRawFileOptions{
+		Ref: &ref,
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
 // LabelList gets a list of labels on a GitLab Project
 func LabelList(projID interface{}) ([]*gitlab.Label, error) {
 	labels := []*gitlab.Label{}
@@ -862,16 +1315,105 @@ func LabelDelete(projID, name string) error {
 	return err
 }
 
-// BranchList get all branches from the project that somehow matches the
-// requested options
-func BranchList(projID interface{}, opts *gitlab.ListBranchesOptions) ([]*gitlab.Branch, error) {
-	branches := []*gitlab.Branch{}
-	for {
-		bList, resp, err := lab.Branches.ListBranches(projID, opts)
+// BranchList get all branches from the project that somehow matches the
+// requested options
+func BranchList(projID interface{}, opts *gitlab.ListBranchesOptions) ([]*gitlab.Branch, error) {
+	branches := []*gitlab.Branch{}
+	for {
+		bList, resp, err := lab.Branches.ListBranches(projID, opts)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, bList...)
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+
+	return branches, nil
+}
+
+// BranchCreate creates a new branch in the project starting from ref
+func BranchCreate(projID interface{}, branch, ref string) (*gitlab.Branch, error) {
+	b, _, err := lab.Branches.CreateBranch(projID, &gitlab.CreateBranchOptions{
+		Branch: &branch,
+		Ref:    &ref,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// BranchDelete removes a branch from the project
+func BranchDelete(projID interface{}, branch string) error {
+	_, err := lab.Branches.DeleteBranch(projID, branch)
+	return err
+}
+
+// ProtectedBranchList gets the protection rules of the branches of a
+// project
+func ProtectedBranchList(projID interface{}) ([]*gitlab.ProtectedBranch, error) {
+	var (
+		list []*gitlab.ProtectedBranch
+		opts gitlab.ListProtectedBranchesOptions
+	)
+	opts.PerPage = maxItemsPerPage
+	for {
+		branches, resp, err := lab.ProtectedBranches.ListProtectedBranches(projID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, branches...)
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+
+	return list, nil
+}
+
+// BranchProtect protects the branches matching a name or wildcard
+func BranchProtect(projID interface{}, opts *gitlab.ProtectRepositoryBranchesOptions) (*gitlab.ProtectedBranch, error) {
+	b, _, err := lab.ProtectedBranches.ProtectRepositoryBranches(projID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// BranchUnprotect removes the protection of a branch name or wildcard
+func BranchUnprotect(projID interface{}, branch string) error {
+	_, err := lab.ProtectedBranches.UnprotectRepositoryBranches(projID, branch)
+	return err
+}
+
+// TagList gets the tags of a project, the most recently updated first by
+// default
+func TagList(projID interface{}, opts gitlab.ListTagsOptions, n int) ([]*gitlab.Tag, error) {
+	var list []*gitlab.Tag
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		tags, resp, err := lab.Tags.ListTags(projID, &opts)
 		if err != nil {
 			return nil, err
 		}
-		branches = append(branches, bList...)
+		list = append(list, tags...)
+
+		if len(list) == n {
+			break
+		}
 
 		var ok bool
 		if opts.Page, ok = hasNextPage(resp); !ok {
@@ -879,7 +1421,40 @@ func BranchList(projID interface{}, opts *gitlab.ListBranchesOptions) ([]*gitlab
 		}
 	}
 
-	return branches, nil
+	return list, nil
+}
+
+// TagGet gets a single tag of a project
+func TagGet(projID interface{}, tag string) (*gitlab.Tag, error) {
+	t, _, err := lab.Tags.GetTag(projID, tag)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TagCreate creates a tag from ref, which is annotated when a message is
+// given
+func TagCreate(projID interface{}, tag, ref, message string) (*gitlab.Tag, error) {
+	opts := &gitlab.CreateTagOptions{
+		TagName: &tag,
+		Ref:     &ref,
+	}
+	if message != "" {
+		opts.Message = &message
+	}
+
+	t, _, err := lab.Tags.CreateTag(projID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TagDelete removes a tag from the project
+func TagDelete(projID interface{}, tag string) error {
+	_, err := lab.Tags.DeleteTag(projID, tag)
+	return err
 }
 
 // MilestoneGet get a specific milestone from the list of available ones
@@ -986,6 +1561,175 @@ func MilestoneDelete(projID, name string) error {
 	return err
 }
 
+// ReleaseList gets the releases of a project, most recent first
+func ReleaseList(projID interface{}, n int) ([]*gitlab.Release, error) {
+	var (
+		list []*gitlab.Release
+		opts gitlab.ListReleasesOptions
+	)
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		releases, resp, err := lab.Releases.ListReleases(projID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, releases...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+
+	return list, nil
+}
+
+// ReleaseGet gets the release of a tag, or the latest release of the
+// project when tag is empty
+func ReleaseGet(projID interface{}, tag string) (*gitlab.Release, error) {
+	var (
+		release *gitlab.Release
+		err     error
+	)
+	if tag == "" {
+		release, _, err = lab.Releases.GetLatestRelease(projID)
+	} else {
+		release, _, err = lab.Releases.GetRelease(projID, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// ReleaseCreate creates a release, along with its tag when it doesn't
+// exist yet
+func ReleaseCreate(projID interface{}, opts *gitlab.CreateReleaseOptions) (*gitlab.Release, error) {
+	release, _, err := lab.Releases.CreateRelease(projID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// ReleaseUpdate updates the name, notes or milestones of a release
+func ReleaseUpdate(projID interface{}, tag string, opts *gitlab.UpdateReleaseOptions) (*gitlab.Release, error) {
+	release, _, err := lab.Releases.UpdateRelease(projID, tag, opts)
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// ReleaseDelete deletes a release; its tag is kept
+func ReleaseDelete(projID interface{}, tag string) error {
+	_, _, err := lab.Releases.DeleteRelease(projID, tag)
+	return err
+}
+
+// ReleaseLinkCreate adds an asset link to a release
+func ReleaseLinkCreate(projID interface{}, tag string, opts *gitlab.CreateReleaseLinkOptions) (*gitlab.ReleaseLink, error) {
+	link, _, err := lab.ReleaseLinks.CreateReleaseLink(projID, tag, opts)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// ReleaseLinkDelete removes an asset link from a release
+func ReleaseLinkDelete(projID interface{}, tag string, id int) error {
+	_, _, err := lab.ReleaseLinks.DeleteReleaseLink(projID, tag, id)
+	return err
+}
+
+// ProjectUploadFile uploads a file to a project, returning its absolute URL
+func ProjectUploadFile(projID interface{}, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	p, err := FindProject(projID)
+	if err != nil {
+		return "", err
+	}
+
+	upload, _, err := lab.Projects.UploadFile(p.ID, f, filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	return p.WebURL + upload.URL, nil
+}
+
+// PackagePublishFile publishes a file in the generic package registry of a
+// project, returning its download URL
+func PackagePublishFile(projID interface{}, pkg, version, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+	_, _, err = lab.GenericPackages.PublishPackageFile(projID, pkg, version, name, f, nil)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := lab.GenericPackages.FormatPackageURL(projID, pkg, version, name)
+	if err != nil {
+		return "", err
+	}
+	return lab.BaseURL().String() + u, nil
+}
+
+// Download fetches a file, authenticating the request when it targets the
+// GitLab instance
+func Download(url string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(url, host+"/") {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+
+	// The token is only meant for the instance, not for the hosts it may
+	// redirect to, e.g. an object storage serving the file
+	client := *httpClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if req.URL.Host != lab.BaseURL().Host {
+			req.Header.Del("PRIVATE-TOKEN")
+		}
+		return nil
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("could not download %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
 // ProjectSnippetCreate creates a snippet in a project
 func ProjectSnippetCreate(projID interface{}, opts *gitlab.CreateProjectSnippetOptions) (*gitlab.Snippet, error) {
 	snip, _, err := lab.ProjectSnippets.CreateSnippet(projID, opts)
@@ -1184,6 +1928,125 @@ func GroupSearch(query string) (*gitlab.Group, error) {
 	return nil, errors.New(msg)
 }
 
+// ProjectEvents gets the events of a project, the most recent first
+func ProjectEvents(projID interface{}, opts gitlab.ListProjectVisibleEventsOptions, n int) ([]*gitlab.ProjectEvent, error) {
+	var list []*gitlab.ProjectEvent
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		events, resp, err := lab.Events.ListProjectVisibleEvents(projID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, events...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// UserEvents gets the contribution events of a user, the most recent first
+func UserEvents(userID interface{}, opts gitlab.ListContributionEventsOptions, n int) ([]*gitlab.ContributionEvent, error) {
+	var list []*gitlab.ContributionEvent
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		events, resp, err := lab.Users.ListUserContributionEvents(userID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, events...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// UserProjectEvents gets the contribution events of a user in a project,
+// paging through the events of the user until n of them are found as the
+// API can't filter them by project
+func UserProjectEvents(userID interface{}, projectID int, opts gitlab.ListContributionEventsOptions, n int) ([]*gitlab.ContributionEvent, error) {
+	var list []*gitlab.ContributionEvent
+	opts.PerPage = maxItemsPerPage
+	for {
+		events, resp, err := lab.Users.ListUserContributionEvents(userID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			if e.ProjectID != projectID {
+				continue
+			}
+			list = append(list, e)
+			if len(list) == n {
+				return list, nil
+			}
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
+// PipelineList gets the pipelines of a project, the most recent first
+func PipelineList(projID interface{}, opts gitlab.ListProjectPipelinesOptions, n int) ([]*gitlab.PipelineInfo, error) {
+	var list []*gitlab.PipelineInfo
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
+		pipelines, resp, err := lab.Pipelines.ListProjectPipelines(projID, &opts)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, pipelines...)
+
+		if len(list) == n {
+			break
+		}
+
+		var ok bool
+		if opts.Page, ok = hasNextPage(resp); !ok {
+			break
+		}
+	}
+	return list, nil
+}
+
 // CIJobs returns a list of jobs in the pipeline with given id.
 // This function by default doesn't follow bridge jobs.
 // The jobs are returned sorted by their CreatedAt time
@@ -1449,6 +2312,18 @@ func UserIDFromEmail(email string) (int, error) {
 	return us[0].ID, nil
 }
 
+// UsernameFromEmail returns the username of the GitLab user with the given
+// email. An empty string is returned if no user is found.
+func UsernameFromEmail(email string) (string, error) {
+	us, _, err := lab.Users.ListUsers(&gitlab.ListUsersOptions{
+		Search: gitlab.String(email),
+	})
+	if err != nil || len(us) == 0 {
+		return "", err
+	}
+	return us[0].Username, nil
+}
+
 // AddMRDiscussionNote adds a note to an existing MR discussion on GitLab
 func AddMRDiscussionNote(projID interface{}, mrID int, discussionID string, body string) (string, error) {
 	opts := &gitlab.AddMergeRequestDiscussionNoteOptions{
@@ -1603,6 +2478,17 @@ func GetMRApprovalsConfiguration(projID interface{}, id int) (*gitlab.MergeReque
 	return configuration, err
 }
 
+// GetMRApprovalState returns the approval rules of an MR along with the
+// current state (eligible approvers, who approved) of each one of them
+func GetMRApprovalState(projID interface{}, id int) (*gitlab.MergeRequestApprovalState, error) {
+	state, _, err := lab.MergeRequestApprovals.GetApprovalState(projID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
 // ResolveMRDiscussion resolves a discussion (blocking thread) based on its ID
 func ResolveMRDiscussion(projID interface{}, mrID int, discussionID string, noteID int) (string, error) {
 	opts := &gitlab.ResolveMergeRequestDiscussionOptions{
@@ -1623,19 +2509,26 @@ func ResolveMRDiscussion(projID interface{}, mrID int, discussionID string, note
 
 // TodoList retuns a list of *gitlab.Todo refering to user's Todo list
 func TodoList(opts gitlab.ListTodosOptions, n int) ([]*gitlab.Todo, error) {
-	if n == -1 {
-		n = maxItemsPerPage
-	}
-
 	var list []*gitlab.Todo
-	for len(list) < n {
-		opts.PerPage = n - len(list)
+	for {
+		opts.PerPage = maxItemsPerPage
+		if n != -1 {
+			opts.PerPage = n - len(list)
+			if opts.PerPage > maxItemsPerPage {
+				opts.PerPage = maxItemsPerPage
+			}
+		}
+
 		todos, resp, err := lab.Todos.ListTodos(&opts)
 		if err != nil {
 			return nil, err
 		}
 		list = append(list, todos...)
 
+		if len(list) == n {
+			break
+		}
+
 		var ok bool
 		if opts.Page, ok = hasNextPage(resp); !ok {
 			break
@@ -32,7 +32,7 @@ func main() {
 			lab.Init(ctx, h, u, t, skipVerify)
 		}
 	}
-	cmd.Execute(initSkipped)
+	cmd.Execute(ctx, initSkipped)
 }
 
 func skipInit() bool {